@@ -0,0 +1,119 @@
+package assert
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// InDelta asserts that the numbers got and want differ by no more than delta.
+// They may be of any numeric type.
+func InDelta(t testingT, got, want interface{}, delta float64) bool {
+	t.Helper()
+	g, w, ok := toFloats(t, "InDelta", got, want)
+	if !ok {
+		return false
+	}
+	if diff := math.Abs(g - w); !(diff <= delta) {
+		msg := fmt.Sprintf("(%v) is not within %v of %v (difference %v)", got, delta, want, diff)
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	return true
+}
+
+// InEpsilon asserts that the relative error between the numbers got and want,
+// |got-want|/|want|, is no more than epsilon. They may be of any numeric type.
+// If want is zero, got must also be zero.
+func InEpsilon(t testingT, got, want interface{}, epsilon float64) bool {
+	t.Helper()
+	g, w, ok := toFloats(t, "InEpsilon", got, want)
+	if !ok {
+		return false
+	}
+	if g == w {
+		return true
+	}
+	if relErr := math.Abs(g-w) / math.Abs(w); !(relErr <= epsilon) {
+		msg := fmt.Sprintf("(%v) is not within relative error %v of %v (relative error %v)", got, epsilon, want, relErr)
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	return true
+}
+
+// toFloats converts the numbers given to the assertion fn to float64s,
+// reporting an error if either isn't a number.
+func toFloats(t testingT, fn string, got, want interface{}) (g, w float64, ok bool) {
+	t.Helper()
+	if g, ok = toFloat(got); !ok {
+		msg := fmt.Sprintf("has unsupported type for %s: %T", fn, got)
+		t.Error(formatError(getArg(1)(), msg))
+		return 0, 0, false
+	}
+	if w, ok = toFloat(want); !ok {
+		t.Error(fmt.Sprintf("want has unsupported type for %s: %T", fn, want))
+		return 0, 0, false
+	}
+	return g, w, true
+}
+
+// WithinDuration asserts that the times got and want differ by no more than
+// delta.
+func WithinDuration(t testingT, got, want time.Time, delta time.Duration) bool {
+	t.Helper()
+	diff := got.Sub(want)
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > delta {
+		msg := fmt.Sprintf("(%v) is not within %v of %v (difference %v)", got, delta, want, diff)
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	return true
+}
+
+// FloatTolerance configures assertions to consider two floating point numbers
+// equal if they differ by no more than margin, wherever they appear in the
+// values being compared. As JSON numbers are represented as float64, it
+// applies to all numbers compared by the JSON assertions. For example:
+//
+//     assert.Equal(t, quote, want, assert.FloatTolerance(0.001))
+func FloatTolerance(margin float64) cmp.Option {
+	return cmpopts.EquateApprox(0, margin)
+}
+
+// TimeTolerance configures assertions to consider two times equal if they
+// differ by no more than margin, wherever they appear in the values being
+// compared. As times are represented as strings in JSON, it also applies to
+// pairs of strings which are both RFC 3339 timestamps, so that it can be used
+// with the JSON assertions. It panics if margin is negative.
+func TimeTolerance(margin time.Duration) cmp.Option {
+	if margin < 0 {
+		panic(fmt.Sprintf("assert: TimeTolerance: margin must be non-negative, got %v", margin))
+	}
+	return cmp.Options{
+		cmpopts.EquateApproxTime(margin),
+		cmp.FilterValues(func(x, y string) bool {
+			_, okx := parseTimestamp(x)
+			_, oky := parseTimestamp(y)
+			return okx && oky
+		}, cmp.Comparer(func(x, y string) bool {
+			tx, _ := parseTimestamp(x)
+			ty, _ := parseTimestamp(y)
+			d := tx.Sub(ty)
+			return -margin <= d && d <= margin
+		})),
+	}
+}
+
+// parseTimestamp parses an RFC 3339 timestamp, as produced by marshaling a
+// time.Time to JSON.
+func parseTimestamp(s string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return t, err == nil
+}
@@ -0,0 +1,83 @@
+package assert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInDelta(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		return InDelta(mt, 10.04, 10, 0.05)
+	}, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		return InDelta(mt, int64(98), uint8(100), 2)
+	}, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		price := 10.5
+		return InDelta(mt, price, 10, 0.25)
+	}, `price (10.5) is not within 0.25 of 10 (difference 0.5)`)
+	assert(t, func(mt *mockTestingT) bool {
+		price := "10"
+		return InDelta(mt, price, 10, 0.25)
+	}, `price has unsupported type for InDelta: string`)
+	assert(t, func(mt *mockTestingT) bool {
+		return InDelta(mt, 10, nil, 0.25)
+	}, `want has unsupported type for InDelta: <nil>`)
+}
+
+func TestInEpsilon(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		return InEpsilon(mt, 101, 100, 0.01)
+	}, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		return InEpsilon(mt, 0, 0, 0.01)
+	}, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		ratio := 1.5
+		return InEpsilon(mt, ratio, 1, 0.1)
+	}, `ratio (1.5) is not within relative error 0.1 of 1 (relative error 0.5)`)
+	assert(t, func(mt *mockTestingT) bool {
+		ratio := 0.5
+		return InEpsilon(mt, ratio, 0, 0.1)
+	}, `ratio (0.5) is not within relative error 0.1 of 0 (relative error +Inf)`)
+}
+
+func TestWithinDuration(t *testing.T) {
+	want := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	assert(t, func(mt *mockTestingT) bool {
+		return WithinDuration(mt, want.Add(-time.Second), want, time.Second)
+	}, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		eta := want.Add(1500 * time.Millisecond)
+		return WithinDuration(mt, eta, want, time.Second)
+	}, `eta (2024-01-01 10:00:01.5 +0000 UTC) is not within 1s of 2024-01-01 10:00:00 +0000 UTC (difference 1.5s)`)
+}
+
+func TestTimeTolerance(t *testing.T) {
+	type quote struct {
+		Price float64
+		ETA   time.Time
+	}
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	got := []quote{{Price: 10.0004, ETA: now.Add(400 * time.Millisecond)}}
+	want := []quote{{Price: 10, ETA: now}}
+
+	assert(t, func(mt *mockTestingT) bool {
+		return Equal(mt, got, want, FloatTolerance(0.001), TimeTolerance(time.Second))
+	}, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		return Equal(mt, got, want, FloatTolerance(0.001), TimeTolerance(100*time.Millisecond))
+	}, `got (-got +want):`)
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONEqual(mt, got, want, FloatTolerance(0.001), TimeTolerance(time.Second))
+	}, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONPath(mt, got, "[0].ETA", now.Add(time.Second), TimeTolerance(time.Second))
+	}, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONPath(mt, got, "[0].ETA", now.Add(2*time.Second), TimeTolerance(time.Second))
+	}, removeLeadingTabs(`
+		got $[0].ETA (-got +want):
+		- "2024-01-01T10:00:00.4Z"
+		+ "2024-01-01T10:00:02Z"`)[1:])
+}
@@ -10,9 +10,12 @@
 // example, to indicate that unexported fields should be ignored on MyType, you
 // can use:
 //
-//      assert.RegisterOptions(
-//          cmpopts.IgnoreUnexported(MyType{}),
-//      )
+//	assert.RegisterOptions(
+//	    cmpopts.IgnoreUnexported(MyType{}),
+//	)
+//
+// Options registered this way apply to every test in the package. To apply
+// options to a single test, wrap t using WithOptions instead.
 //
 // See the go-cmp docs for more options:
 // https://godoc.org/github.com/google/go-cmp/cmp.
@@ -20,20 +23,25 @@ package assert
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"io"
 	"io/ioutil"
 	"os"
 	"reflect"
 	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"unicode/utf8"
 
+	"github.com/deliveroo/assert-go/internal/jsonpath"
 	"github.com/google/go-cmp/cmp"
-	"github.com/oliveagle/jsonpath"
 )
 
 // testingT is a simplified interface of the testing.T.
@@ -43,46 +51,116 @@ type testingT interface {
 	Fatal(args ...interface{})
 }
 
+// wrapper is implemented by the testingTs in this package which wrap another
+// testingT.
+type wrapper interface {
+	unwrap() testingT
+}
+
 // defaultOpts is the default set of options passed to cmp.Diff for
-// assert.Equals.
-var defaultOpts = []cmp.Option{
-	// Compare errors by their messages.
-	cmp.Comparer(func(x, y error) bool {
-		if x == nil && y == nil {
-			return true
-		} else if x != nil && y != nil {
-			return x.Error() == y.Error()
+// assert.Equals. It is guarded by defaultOptsMu.
+var (
+	defaultOpts   []cmp.Option
+	defaultOptsMu sync.RWMutex
+)
+
+// withDefaults returns opts combined with any options carried by t (see
+// WithOptions) and the registered default options. At most one error
+// comparison (see CompareErrors) is included: the one passed in opts if any,
+// otherwise the one carried by t, otherwise the registered one, otherwise
+// ErrorsByMessage.
+func withDefaults(t testingT, opts []cmp.Option) []cmp.Option {
+	return unwrapOptions(collectOptions(t, opts))
+}
+
+// collectOptions is like withDefaults, but leaves the options of this package
+// which cmp doesn't accept (see unwrapOptions) in place, for the assertions
+// which interpret them.
+func collectOptions(t testingT, opts []cmp.Option) []cmp.Option {
+	groups := [][]cmp.Option{opts}
+	for t != nil {
+		if s, ok := t.(*Scoped); ok {
+			groups = append(groups, s.opts)
 		}
-		return false
-	}),
+		w, ok := t.(wrapper)
+		if !ok {
+			break
+		}
+		t = w.unwrap()
+	}
+	defaultOptsMu.RLock()
+	groups = append(groups, defaultOpts)
+	defaultOptsMu.RUnlock()
+
+	var mode cmp.Option
+	var result []cmp.Option
+	for _, group := range groups {
+		_, group = splitAnnotations(group)
+		m, rest := splitErrorComparer(group)
+		if mode == nil {
+			mode = m
+		}
+		result = append(result, rest...)
+	}
+	if mode == nil {
+		mode = errorComparers[ErrorsByMessage]
+	}
+	return append(result, mode, newMatcherComparer())
+}
+
+// unwrapOptions returns opts with the options created by Ignore, Only,
+// IgnoreJSON and UnorderedArrays, which cmp doesn't accept, replaced by the
+// options they wrap. For UnorderedArrays, which only the JSON assertions
+// interpret, that is nil.
+func unwrapOptions(opts []cmp.Option) []cmp.Option {
+	result := make([]cmp.Option, 0, len(opts))
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case cmp.Options:
+			result = append(result, cmp.Options(unwrapOptions(opt)))
+		case pathOption:
+			result = append(result, opt.Option)
+		case ignoreJSON:
+			result = append(result, opt.Option)
+		case unorderedArrays:
+			result = append(result, opt.Option)
+		default:
+			result = append(result, opt)
+		}
+	}
+	return result
 }
 
 // RegisterOptions registers a default option for all tests in the current
 // package. It's intended to be used in an init function, like:
 //
-//     func init() {
-//         assert.RegisterOptions(
-//             cmp.Comparer(func(x, y *Thing) bool {
-//                 return x.ID == y.ID
-//             }),
-//         )
-//     }
+//	func init() {
+//	    assert.RegisterOptions(
+//	        cmp.Comparer(func(x, y *Thing) bool {
+//	            return x.ID == y.ID
+//	        }),
+//	    )
+//	}
 //
 // Note that due to how "go test" operates, these options will not leak between
-// packages.
+// packages. To apply options to a single test only, use WithOptions.
 func RegisterOptions(opts ...cmp.Option) {
+	defaultOptsMu.Lock()
+	defer defaultOptsMu.Unlock()
 	defaultOpts = append(defaultOpts, opts...)
 }
 
 // Equal asserts that got and want are assertEqual.
 func Equal(t testingT, got, want interface{}, opts ...cmp.Option) bool {
 	t.Helper()
+	t, opts = annotate(t, opts)
 	return assertEqual(t, getArg(1), got, want, opts)
 }
 
 // NotEqual asserts that got and want are not equal.
 func NotEqual(t testingT, got, want interface{}, opts ...cmp.Option) bool {
 	t.Helper()
+	t, opts = annotate(t, opts)
 	return assertNotEqual(t, getArg(1), got, want, opts)
 }
 
@@ -103,46 +181,276 @@ func ErrorContains(t testingT, got error, want string) bool {
 }
 
 // JSONEqual asserts that got and want are equal when represented as JSON. If
-// either are already strings, they will be considered raw JSON. Otherwise, they
-// will be marshaled to JSON before comparison.
+// either are strings beginning with `{` or `[`, byte slices, json.RawMessages or
+// io.Readers, they will be considered raw JSON. Otherwise, they will be
+// marshaled to JSON before comparison. Invalid JSON is reported as a failure.
 func JSONEqual(t testingT, got, want interface{}, opts ...cmp.Option) bool {
 	t.Helper()
-	return assertEqual(t, getArg(1), toJSON(got), toJSON(want), opts)
+	t, opts = annotate(t, opts)
+	got, ok := decodeJSON(t, 1, got)
+	if !ok {
+		return false
+	}
+	want, ok = decodeJSON(t, 2, want)
+	if !ok {
+		return false
+	}
+	return assertJSONEqual(t, getArg(1), got, want, opts)
 }
 
 // JSONPath asserts that evaluating the path expression against the subject
 // results in want. The subject and want parameters are both converted to their
 // JSON representation before being evaluated.
+//
+// The path is a JSONPath query as specified by RFC 9535, and may omit the
+// leading "$." (e.g. "user.id"). If it selects at most one value (i.e. it uses
+// only names and indexes), that value is compared against want. Otherwise, the
+// list of selected values is compared against want.
 func JSONPath(t testingT, subject interface{}, path string, want interface{}, opts ...cmp.Option) bool {
 	t.Helper()
-	subject, want = toJSON(subject), toJSON(want)
-	if !strings.HasPrefix(path, "$.") {
-		path = "$." + path
+	t, opts = annotate(t, opts)
+	subject, ok := decodeJSON(t, 1, subject)
+	if !ok {
+		return false
 	}
-	var err interface{}
-	got, err := jsonpath.JsonPathLookup(subject, path)
+	want, ok = decodeJSON(t, 3, want)
+	if !ok {
+		return false
+	}
+	path = jsonPathQuery(path)
+	got, err := jsonpath.Lookup(subject, path)
 	if err != nil {
-		t.Error(err)
+		t.Error(formatJSONPathError(getArg(1)(), err))
 		return false
 	}
-	return assertEqual(t, func() string { return path }, got, want, opts)
+	expr := getArg(1)
+	return assertJSONEqual(t, func() string { return formatError(expr(), path) }, got, want, opts)
 }
 
 // JSONLookup fetches a value from a JSON object using the path expression.
+// The subject is converted to its JSON representation before being evaluated,
+// so the value returned has the types encoding/json decodes to: numbers are
+// float64s, objects map[string]interface{} and arrays []interface{}, even if
+// the subject held ints or structs.
 func JSONLookup(t testingT, subject interface{}, path string) interface{} {
 	t.Helper()
-	if !strings.HasPrefix(path, "$.") {
-		path = "$." + path
+	subject, err := toJSON(subject)
+	if err != nil {
+		t.Fatal(formatError(getArg(1)(), err.Error()))
+		return nil
+	}
+	got, err := jsonpath.Lookup(subject, jsonPathQuery(path))
+	if err != nil {
+		t.Fatal(formatJSONPathError(getArg(1)(), err))
 	}
-	got, err := jsonpath.JsonPathLookup(subject, path)
-	Must(t, err)
 	return got
 }
 
+// JSONPathExists asserts that evaluating the path expression against the
+// subject selects a value. The subject is converted to its JSON representation
+// before being evaluated.
+func JSONPathExists(t testingT, subject interface{}, path string) bool {
+	t.Helper()
+	p, err := jsonpath.Parse(jsonPathQuery(path))
+	if err != nil {
+		t.Error(formatJSONPathError(getArg(1)(), err))
+		return false
+	}
+	subject, ok := decodeJSON(t, 1, subject)
+	if !ok {
+		return false
+	}
+	got, err := p.Get(subject)
+	if err != nil {
+		t.Error(formatJSONPathError(getArg(1)(), err))
+		return false
+	}
+	if nodes, ok := got.([]interface{}); ok && !p.Singular() && len(nodes) == 0 {
+		t.Error(formatError(getArg(1)(), p.String()+" selected nothing"))
+		return false
+	}
+	return true
+}
+
+// JSONPathNotExists asserts that evaluating the path expression against the
+// subject selects nothing. The subject is converted to its JSON representation
+// before being evaluated. It's useful for checking that a field is absent,
+// for example:
+//
+//	assert.JSONPathNotExists(t, resp, "user.password")
+func JSONPathNotExists(t testingT, subject interface{}, path string) bool {
+	t.Helper()
+	p, err := jsonpath.Parse(jsonPathQuery(path))
+	if err != nil {
+		t.Error(formatJSONPathError(getArg(1)(), err))
+		return false
+	}
+	subject, ok := decodeJSON(t, 1, subject)
+	if !ok {
+		return false
+	}
+	var got interface{}
+	if p.Singular() {
+		if got, err = p.Get(subject); err != nil {
+			return true
+		}
+	} else {
+		nodes := p.Select(subject)
+		if len(nodes) == 0 {
+			return true
+		}
+		got = nodes
+	}
+	msg := fmt.Sprintf("%s exists: %s", p, jsonSnippet(got))
+	t.Error(formatError(getArg(1)(), msg))
+	return false
+}
+
+// JSONPathMatches asserts that evaluating the path expression against the
+// subject results in a value for which match returns true. The subject is
+// converted to its JSON representation before being evaluated, so match is
+// passed simple JSON types (e.g. float64 for numbers). For example:
+//
+//	assert.JSONPathMatches(t, resp, "user.id", func(v interface{}) bool {
+//	    id, ok := v.(string)
+//	    return ok && strings.HasPrefix(id, "usr_")
+//	})
+func JSONPathMatches(t testingT, subject interface{}, path string, match func(v interface{}) bool) bool {
+	t.Helper()
+	subject, ok := decodeJSON(t, 1, subject)
+	if !ok {
+		return false
+	}
+	path = jsonPathQuery(path)
+	got, err := jsonpath.Lookup(subject, path)
+	if err != nil {
+		t.Error(formatJSONPathError(getArg(1)(), err))
+		return false
+	}
+	if !match(got) {
+		msg := fmt.Sprintf("%s (%s) does not match", path, jsonSnippet(got))
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	return true
+}
+
+// formatJSONPathError formats an error evaluating a JSONPath query against the
+// subject expr. If the query selected nothing, the message includes the
+// nearest node that exists and, if it's an object, its keys.
+func formatJSONPathError(expr string, err error) string {
+	msg := formatError(expr, err.Error())
+	var nf *jsonpath.NotFoundError
+	if !errors.As(err, &nf) {
+		return msg
+	}
+	msg += fmt.Sprintf("\nnearest parent %s: %s", nf.Parent, jsonSnippet(nf.Node))
+	if m, ok := nf.Node.(map[string]interface{}); ok {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, strconv.Quote(k))
+		}
+		sort.Strings(keys)
+		if len(keys) == 0 {
+			msg += "\navailable keys: none"
+		} else {
+			msg += "\navailable keys: " + strings.Join(keys, ", ")
+		}
+	}
+	return msg
+}
+
+// maxSnippet is the maximum length of JSON included in failure messages.
+const maxSnippet = 200
+
+// jsonSnippet returns the compact JSON encoding of v, truncated if it's long.
+func jsonSnippet(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if len(b) > maxSnippet {
+		return string(b[:maxSnippet]) + "..."
+	}
+	return string(b)
+}
+
+// jsonPathQuery adds the root identifier to path if it was omitted.
+func jsonPathQuery(path string) string {
+	switch {
+	case strings.HasPrefix(path, "$"):
+	case strings.HasPrefix(path, "[") || strings.HasPrefix(path, "."):
+		path = "$" + path
+	default:
+		path = "$." + path
+	}
+	return quoteMemberNames(path)
+}
+
+// quoteMemberNames rewrites the shorthand member names in query which contain
+// characters RFC 9535 doesn't allow in them, such as "-", in bracket notation,
+// so that paths like "user-id" keep working: "$.user-id" becomes
+// "$['user-id']". Names within brackets, such as in filters, are left alone.
+func quoteMemberNames(query string) string {
+	var b strings.Builder
+	depth := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(query) {
+				b.WriteByte(c)
+				i++
+				c = query[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '.' && depth == 0:
+			start := i + 1
+			if start < len(query) && query[start] == '.' {
+				start++
+			}
+			end := start
+			for end < len(query) && query[end] != '.' && query[end] != '[' {
+				end++
+			}
+			name := query[start:end]
+			if strings.IndexFunc(name, isNotNameChar) == -1 || name == "*" {
+				break
+			}
+			if start-i == 2 {
+				b.WriteString("..")
+			}
+			b.WriteString("['" + nameEscaper.Replace(name) + "']")
+			i = end - 1
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// nameEscaper escapes a member name for use in single quotes.
+var nameEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// isNotNameChar reports whether r can't appear in a shorthand member name.
+func isNotNameChar(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		r == '_' || r >= 0x80 && r != utf8.RuneError)
+}
+
 // Contains asserts that got contains want.
 // The got parameter can be either a string or slice.
 func Contains(t testingT, got, want interface{}, opts ...cmp.Option) bool {
 	t.Helper()
+	t, opts = annotate(t, opts)
 
 	switch reflect.TypeOf(got).Kind() {
 	case reflect.String:
@@ -168,9 +476,12 @@ func Contains(t testingT, got, want interface{}, opts ...cmp.Option) bool {
 }
 
 // ContainsAll asserts that got contains all items of want.
-// The got and want parameters must be slices.
+// The got and want parameters must be slices. As with the other assertions,
+// options registered with RegisterOptions or carried by t (see WithOptions)
+// apply in addition to opts, and errors are compared by message by default.
 func ContainsAll(t testingT, got, want interface{}, opts ...cmp.Option) bool {
 	t.Helper()
+	t, opts = annotate(t, opts)
 
 	gotKind := reflect.TypeOf(got).Kind()
 
@@ -182,6 +493,7 @@ func ContainsAll(t testingT, got, want interface{}, opts ...cmp.Option) bool {
 			t.Error("want must be slice")
 			return false
 		}
+		opts = withDefaults(t, opts)
 		missing = sliceContainsAll(castInterfaceToSlice(want), castInterfaceToSlice(got), opts...)
 	default:
 		msg := fmt.Sprintf("has unsupported type for ContainsAll: %q", reflect.TypeOf(got).Kind())
@@ -313,20 +625,43 @@ func isNil(v interface{}) bool {
 }
 
 func assertEqual(t testingT, expr func() string, got, want interface{}, opts []cmp.Option) bool {
-	defer func() {
-		if err := recover(); err != nil {
-			t.Error("diff error:", err)
-		}
-	}()
 	t.Helper()
-	opts = append(opts, defaultOpts...)
-	if diff := cmp.Diff(got, want, opts...); diff != "" {
+	rec, err := checkPathPatterns(got, opts)
+	if err != nil {
+		t.Error("invalid option: " + err.Error())
+		return false
+	}
+	opts = withDefaults(t, opts)
+	if rec != nil {
+		opts = append(opts, cmp.Reporter(rec))
+	}
+	diff, err := diffValues(got, want, opts)
+	if err != nil {
+		t.Error("diff error:", err)
+		return false
+	}
+	if err := rec.check(); err != nil {
+		t.Error("invalid option: " + err.Error())
+		return false
+	}
+	if diff != "" {
 		t.Error(formatDiff(expr(), "(-got +want): ", diff))
 		return false
 	}
 	return true
 }
 
+// diffValues returns the cmp.Diff of got and want, converting a panic from cmp
+// (e.g. on unexported fields) into an error.
+func diffValues(got, want interface{}, opts []cmp.Option) (diff string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return cmp.Diff(got, want, opts...), nil
+}
+
 func assertNotEqual(t testingT, expr func() string, got, notWant interface{}, opts []cmp.Option) bool {
 	defer func() {
 		if err := recover(); err != nil {
@@ -334,8 +669,21 @@ func assertNotEqual(t testingT, expr func() string, got, notWant interface{}, op
 		}
 	}()
 	t.Helper()
-	opts = append(opts, defaultOpts...)
-	if diff := cmp.Diff(got, notWant, opts...); diff == "" {
+	rec, err := checkPathPatterns(got, opts)
+	if err != nil {
+		t.Error("invalid option: " + err.Error())
+		return false
+	}
+	opts = withDefaults(t, opts)
+	if rec != nil {
+		opts = append(opts, cmp.Reporter(rec))
+	}
+	diff := cmp.Diff(got, notWant, opts...)
+	if err := rec.check(); err != nil {
+		t.Error("invalid option: " + err.Error())
+		return false
+	}
+	if diff == "" {
 		msg := fmt.Sprintf("should not equal %#v", notWant)
 		t.Error(formatError(expr(), msg))
 		return false
@@ -353,8 +701,8 @@ func castInterfaceToSlice(inter interface{}) []interface{} {
 }
 
 func sliceContains(t testingT, got []interface{}, want interface{}, expr string, opts ...cmp.Option) bool {
+	opts = withDefaults(t, opts)
 	for i := 0; i < len(got); i++ {
-		opts = append(opts, defaultOpts...)
 		if eq := cmp.Equal(got[i], want, opts...); eq {
 			return true
 		}
@@ -382,17 +730,34 @@ outerLoop:
 
 // getArg finds the source code for the given function argument. For example, if
 // function f was called like `f(id)`, getArg(0) would return "id".
+//
+// If the assertion function was itself called from another function in this
+// package or one of its subpackages (e.g. typed.Equal calling Equal), the
+// outermost such function is used, so that the expression is taken from the
+// caller's source.
 func getArg(arg int) func() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
 	// Find the name of the assertion function (e.g. Equal).
-	pc, _, _, _ := runtime.Caller(1)
-	fn := runtime.FuncForPC(pc).Name()
-	if idx := strings.LastIndex(fn, "."); idx != -1 {
-		fn = fn[idx+1:]
+	fn, _ := frames.Next()
+	caller, more := frames.Next()
+	for more && isLibFrame(caller) {
+		fn = caller
+		caller, more = frames.Next()
+	}
+	name := funcName(fn.Function)
+
+	// Methods (e.g. Asserter.Equal) take the place of t with their receiver, so
+	// arguments are one position earlier.
+	if isMethod(fn.Function) {
+		arg--
 	}
 
 	// Open the source code of the calling function, find the function call, and
 	// return the source for the argument.
-	_, filename, line, _ := runtime.Caller(2)
+	filename, line := caller.File, caller.Line
 	return func() string {
 		file, err := os.Open(filename)
 		if err != nil {
@@ -415,7 +780,7 @@ func getArg(arg int) func() string {
 			if fset.Position(n.Pos()).Line == line {
 				switch x := n.(type) {
 				case *ast.CallExpr:
-					if !isFunc(x, fn) {
+					if !isFunc(x, name) || arg < 0 || arg >= len(x.Args) {
 						return true
 					}
 					arg := x.Args[arg]
@@ -438,8 +803,51 @@ func fmtVal(v interface{}) string {
 	}
 }
 
+// funcName returns the unqualified name of a function as reported by the
+// runtime, e.g. "Equal" for "github.com/deliveroo/assert-go/typed.Equal[...]".
+func funcName(fn string) string {
+	if idx := strings.Index(fn, "["); idx != -1 {
+		fn = fn[:idx]
+	}
+	if idx := strings.LastIndex(fn, "."); idx != -1 {
+		fn = fn[idx+1:]
+	}
+	return fn
+}
+
+// isMethod reports whether fn, as reported by the runtime, is the name of a
+// method, e.g. "github.com/deliveroo/assert-go.(*Asserter).Equal".
+func isMethod(fn string) bool {
+	if idx := strings.LastIndex(fn, "/"); idx != -1 {
+		fn = fn[idx+1:]
+	}
+	parts := strings.Split(fn, ".")
+	return len(parts) == 3 && !strings.HasPrefix(parts[2], "func")
+}
+
+// pkgPath is the import path of this package.
+var pkgPath = reflect.TypeOf(Collector{}).PkgPath()
+
+// isLibFrame reports whether the frame belongs to this package or one of its
+// subpackages, excluding their tests.
+func isLibFrame(frame runtime.Frame) bool {
+	if strings.HasSuffix(frame.File, "_test.go") {
+		return false
+	}
+	return strings.HasPrefix(frame.Function, pkgPath+".") ||
+		strings.HasPrefix(frame.Function, pkgPath+"/")
+}
+
 func isFunc(expr *ast.CallExpr, name string) bool {
-	switch x := expr.Fun.(type) {
+	fun := expr.Fun
+	// Strip explicit type arguments, e.g. typed.Equal[int].
+	switch x := fun.(type) {
+	case *ast.IndexExpr:
+		fun = x.X
+	case *ast.IndexListExpr:
+		fun = x.X
+	}
+	switch x := fun.(type) {
 	case *ast.SelectorExpr:
 		return x.Sel.Name == name
 	case *ast.Ident:
@@ -448,32 +856,110 @@ func isFunc(expr *ast.CallExpr, name string) bool {
 	return false
 }
 
-// toJSON transforms v into simple JSON types (maps and arrays).
-func toJSON(v interface{}) interface{} {
-	// Special case: if v is a string and begins with `[` or `{`, assume it's a
-	// raw JSON string and unmarshal it directly.
-	if s, ok := v.(string); ok {
-		if strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") {
-			var r interface{}
-			if err := json.Unmarshal([]byte(s), &r); err != nil {
-				panic(err)
-			}
-			return r
-		}
-	}
-	b, err := json.Marshal(v)
+// toJSON transforms v into simple JSON types (maps and arrays). Raw JSON may be
+// given as a []byte, json.RawMessage or io.Reader, or as a string beginning
+// (after any whitespace) with `[` or `{`; anything else is marshaled to JSON.
+func toJSON(v interface{}) (interface{}, error) {
+	raw, ok, err := rawInput(v)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	if s, isString := v.(string); isString && isRawJSON(s) {
+		raw, ok = []byte(s), true
+	}
+	if !ok {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot be represented as JSON: %v", err)
+		}
+		raw = b
 	}
 	var r interface{}
-	if err := json.Unmarshal(b, &r); err != nil {
-		panic(err)
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return nil, jsonSyntaxError(raw, err)
+	}
+	return r, nil
+}
+
+// rawInput returns the contents of v if it's a []byte, json.RawMessage or
+// io.Reader, which the assertions comparing documents treat as raw input.
+func rawInput(v interface{}) (raw []byte, ok bool, err error) {
+	switch x := v.(type) {
+	case []byte:
+		return x, true, nil
+	case json.RawMessage:
+		return x, true, nil
+	case io.Reader:
+		b, err := ioutil.ReadAll(x)
+		if err != nil {
+			return nil, false, fmt.Errorf("could not be read: %v", err)
+		}
+		return b, true, nil
+	}
+	return nil, false, nil
+}
+
+// isRawJSON reports whether s should be treated as raw JSON by toJSON, rather
+// than as a string to be marshaled.
+func isRawJSON(s string) bool {
+	s = strings.TrimLeft(s, " \t\r\n")
+	return strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[")
+}
+
+// snippetContext is the number of bytes either side of a syntax error included
+// in failure messages.
+const snippetContext = 40
+
+// jsonSyntaxError describes err, an error unmarshaling raw, including a snippet
+// of raw around the position of a syntax error.
+func jsonSyntaxError(raw []byte, err error) error {
+	var serr *json.SyntaxError
+	if !errors.As(err, &serr) {
+		return fmt.Errorf("is not valid JSON: %v", err)
+	}
+	// The error occurred after reading Offset bytes, so the offending byte is
+	// the one before, unless the input ended early.
+	offset := int(serr.Offset) - 1
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(raw) || strings.HasPrefix(serr.Error(), "unexpected end") {
+		offset = len(raw)
+	}
+	start, end := offset-snippetContext, offset+snippetContext
+	prefix, suffix := "...", "..."
+	if start <= 0 {
+		start, prefix = 0, ""
+	}
+	if end >= len(raw) {
+		end, suffix = len(raw), ""
+	}
+	// Control characters are replaced so that the caret lines up.
+	snippet := strings.Map(func(r rune) rune {
+		if r < ' ' {
+			return ' '
+		}
+		return r
+	}, string(raw[start:end]))
+	caret := strings.Repeat(" ", len(prefix)+utf8.RuneCount(raw[start:offset])) + "^"
+	return fmt.Errorf("is not valid JSON: %v at offset %d:\n    %s%s%s\n    %s", err, offset, prefix, snippet, suffix, caret)
+}
+
+// decodeJSON converts v, the value of argument arg of the assertion, to simple
+// JSON types as in toJSON. If it can't be, it reports a failure and returns
+// false.
+func decodeJSON(t testingT, arg int, v interface{}) (interface{}, bool) {
+	t.Helper()
+	r, err := toJSON(v)
+	if err != nil {
+		t.Error(formatError(getArg(arg)(), err.Error()))
+		return nil, false
 	}
-	return r
+	return r, true
 }
 
 func formatDiff(expr, prefix, diff string) string {
-	msg := prefix + strings.TrimSpace(diff)
+	msg := prefix + strings.TrimSpace(describeMatchers(diff))
 	if expr != "" {
 		msg = expr + " " + msg
 	}
@@ -1,6 +1,13 @@
 package assert
 
-import "testing"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
 
 func TestIsEmpty(t *testing.T) {
 	type dummy struct{ ID int }
@@ -33,3 +40,131 @@ func TestIsEmpty(t *testing.T) {
 		}
 	}
 }
+
+func TestWithDefaultsErrorMode(t *testing.T) {
+	defer func(opts []cmp.Option) { defaultOpts = opts }(defaultOpts)
+	byMessage := errorComparers[ErrorsByMessage]
+	byIs := errorComparers[ErrorsByIs]
+	byType := errorComparers[ErrorsByTypeAndMessage]
+
+	defaultOpts = nil
+	assertModes(t, withDefaults(nil, nil), byMessage)
+
+	defaultOpts = []cmp.Option{byIs}
+	assertModes(t, withDefaults(nil, nil), byIs)
+	assertModes(t, withDefaults(nil, []cmp.Option{cmp.Options{byType}}), byType)
+}
+
+// assertModes checks that opts contains only the error comparer want.
+func assertModes(t *testing.T, opts []cmp.Option, want cmp.Option) {
+	t.Helper()
+	var found []cmp.Option
+	var walk func([]cmp.Option)
+	walk = func(opts []cmp.Option) {
+		for _, opt := range opts {
+			if nested, ok := opt.(cmp.Options); ok {
+				walk(nested)
+			} else if isErrorComparer(opt) {
+				found = append(found, opt)
+			}
+		}
+	}
+	walk(opts)
+	if len(found) != 1 || found[0] != want {
+		t.Errorf("got error comparers %v, want only %v", found, want)
+	}
+}
+
+func TestIsMethod(t *testing.T) {
+	tests := []struct {
+		fn     string
+		method bool
+	}{
+		{"github.com/deliveroo/assert-go.Equal", false},
+		{"github.com/deliveroo/assert-go.(*Asserter).Equal", true},
+		{"github.com/deliveroo/assert-go.Asserter.Equal", true},
+		{"github.com/deliveroo/assert-go.Equal.func1", false},
+		{"github.com/deliveroo/assert-go/typed.Equal[...]", false},
+	}
+
+	for _, tt := range tests {
+		if got := isMethod(tt.fn); got != tt.method {
+			t.Errorf("%s: got %v, want %v", tt.fn, got, tt.method)
+		}
+	}
+}
+
+func TestJSONSnippet(t *testing.T) {
+	if got := jsonSnippet(map[string]interface{}{"id": 1.0}); got != `{"id":1}` {
+		t.Errorf("got %s", got)
+	}
+	long := jsonSnippet(strings.Repeat("a", 500))
+	if len(long) != maxSnippet+len("...") || !strings.HasSuffix(long, "...") {
+		t.Errorf("got %s, want truncated snippet", long)
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	want := map[string]interface{}{"id": 1.0}
+	for _, v := range []interface{}{
+		`{"id": 1}`,
+		"\n\t {\"id\": 1}",
+		[]byte(`{"id": 1}`),
+		json.RawMessage(`{"id": 1}`),
+		strings.NewReader(`{"id": 1}`),
+		struct {
+			ID int `json:"id"`
+		}{1},
+	} {
+		got, err := toJSON(v)
+		if err != nil {
+			t.Errorf("toJSON(%#v): %v", v, err)
+			continue
+		}
+		if !cmp.Equal(got, want) {
+			t.Errorf("toJSON(%#v) = %v, want %v", v, got, want)
+		}
+	}
+
+	if got, err := toJSON("id"); err != nil || got != "id" {
+		t.Errorf(`toJSON("id") = %v, %v; want "id"`, got, err)
+	}
+
+	_, err := toJSON(`{"id": 1,}`)
+	assertEQ(t, fmt.Sprint(err), "is not valid JSON: invalid character '}' looking for beginning of object key string at offset 9:\n"+
+		"    {\"id\": 1,}\n"+
+		"             ^")
+
+	_, err = toJSON([]byte(`[1, 2`))
+	assertEQ(t, fmt.Sprint(err), "is not valid JSON: unexpected end of JSON input at offset 5:\n"+
+		"    [1, 2\n"+
+		"         ^")
+
+	_, err = toJSON(`{"s": "` + strings.Repeat("a", 100) + `" x}`)
+	assertEQ(t, fmt.Sprint(err), "is not valid JSON: invalid character 'x' after object key:value pair at offset 109:\n"+
+		"    ..."+strings.Repeat("a", 38)+"\" x}\n"+
+		"    "+strings.Repeat(" ", 3+40)+"^")
+
+	_, err = toJSON(make(chan int))
+	assertEQ(t, fmt.Sprint(err), "cannot be represented as JSON: json: unsupported type: chan int")
+}
+
+func TestJSONPathQuery(t *testing.T) {
+	tests := []struct {
+		path, want string
+	}{
+		{"user.id", "$.user.id"},
+		{"[0].id", "$[0].id"},
+		{"$..id", "$..id"},
+		{"user-id", "$['user-id']"},
+		{"$.meta.x-request-id", "$.meta['x-request-id']"},
+		{"..x-id", "$..['x-id']"},
+		{"items[*].sku-code", "$.items[*]['sku-code']"},
+		{"it's", `$['it\'s']`},
+		{"items[?@.a-b == 'x.y-z'].id", "$.items[?@.a-b == 'x.y-z'].id"},
+		{"tags.*", "$.tags.*"},
+	}
+	for _, tt := range tests {
+		assertEQ(t, jsonPathQuery(tt.path), tt.want)
+	}
+}
@@ -98,7 +98,30 @@ func TestAssertJSONEqual(t *testing.T) {
 		func(mt *mockTestingT) bool {
 			return JSONEqual(mt, subject, map[string]interface{}{"id": 2})
 		},
-		`subject (-got +want):`)
+		removeLeadingTabs(`subject (-got +want):
+		/id:
+		  - 1
+		  + 2`),
+	)
+
+	assert(t, func(mt *mockTestingT) bool {
+		body := strings.NewReader(`{"id": 1}`)
+		return JSONEqual(mt, body, []byte(`  {"id": 1}`))
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			resp := `{"id": 1,}`
+			return JSONEqual(mt, resp, subject)
+		},
+		"resp is not valid JSON: invalid character '}' looking for beginning of object key string at offset 9:")
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			want := map[string]interface{}{"ch": make(chan int)}
+			return JSONEqual(mt, subject, want)
+		},
+		"want cannot be represented as JSON: json: unsupported type: chan int")
 }
 
 func TestAssertJSONPath(t *testing.T) {
@@ -114,16 +137,171 @@ func TestAssertJSONPath(t *testing.T) {
 		func(mt *mockTestingT) bool {
 			return JSONPath(mt, subject, "id", "true")
 		},
-		`$.id (-got +want):`)
+		`subject $.id (-got +want):`)
 
 	assert(t,
 		func(mt *mockTestingT) bool {
 			return JSONPath(mt, subject, "nonexistent", 1)
 		},
-		`key error: nonexistent not found in object`,
+		removeLeadingTabs(`subject $.nonexistent: key "nonexistent" not found in object at $
+		nearest parent $: {"id":"false"}
+		available keys: "id"`),
+	)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			resp := `{"user": {"name": "Alice", "address": {"city": "London"}}}`
+			return JSONPath(mt, resp, "user.address.postcode", "N1")
+		},
+		removeLeadingTabs(`resp $.user.address.postcode: key "postcode" not found in object at $.user.address
+		nearest parent $.user.address: {"city":"London"}
+		available keys: "city"`),
+	)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			resp := `{"items": [1, 2]}`
+			return JSONPath(mt, resp, "items[2]", 3)
+		},
+		removeLeadingTabs(`resp $.items[2]: index 2 out of range for array of length 2 at $.items
+		nearest parent $.items: [1,2]`),
+	)
+
+	order := `{"items": [{"sku": "a", "price": 5}, {"sku": "b", "price": 15}]}`
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONPath(mt, order, "items[1].sku", "b")
+	}, ``)
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONPath(mt, order, "$.items[?(@.price > 10)].sku", []string{"b"})
+	}, ``)
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONPath(mt, order, "..sku", []string{"a", "b"})
+	}, ``)
+
+	// Names which aren't valid shorthand names in RFC 9535 are still accepted.
+	headers := `{"user-id": 1, "meta": {"x-request-id": "abc", "a.b": {"c-d": true}}}`
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONPath(mt, headers, "user-id", 1)
+	}, ``)
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONPath(mt, headers, "$.meta.x-request-id", "abc")
+	}, ``)
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONPath(mt, headers, "meta['a.b'].c-d", true)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONPath(mt, headers, "meta.request-id", "abc")
+		},
+		`headers $.meta['request-id']: key "request-id" not found in object at $.meta`,
+	)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONPath(mt, order, "items[?@.price >]", nil)
+		},
+		`order invalid JSONPath "$.items[?@.price >]": expected literal, query or function, found character ']' at offset 18`,
 	)
 }
 
+func TestJSONLookup(t *testing.T) {
+	subject := struct {
+		Items []string `json:"items"`
+	}{[]string{"a", "b"}}
+
+	mt := &mockTestingT{}
+	assertEQ(t, JSONLookup(mt, subject, "items[-1]"), "b")
+	assertEQ(t, mt.fatal, "")
+
+	JSONLookup(mt, subject, "items[2]")
+	assertEQ(t, mt.fatal, "subject $.items[2]: index 2 out of range for array of length 2 at $.items\n"+
+		`nearest parent $.items: ["a","b"]`)
+}
+
+func TestAssertJSONPathExists(t *testing.T) {
+	resp := `{"user": {"name": "Alice", "tags": []}}`
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONPathExists(mt, resp, "user.name")
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONPathExists(mt, resp, "user.email")
+		},
+		removeLeadingTabs(`resp $.user.email: key "email" not found in object at $.user
+		nearest parent $.user: {"name":"Alice","tags":[]}
+		available keys: "name", "tags"`),
+	)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONPathExists(mt, resp, "user.tags[*]")
+		},
+		`resp $.user.tags[*] selected nothing`)
+}
+
+func TestAssertJSONPathNotExists(t *testing.T) {
+	resp := `{"user": {"name": "Alice", "ssn": "123-45-6789"}}`
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONPathNotExists(mt, resp, "user.password")
+	}, ``)
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONPathNotExists(mt, resp, "..password")
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONPathNotExists(mt, resp, "user.ssn")
+		},
+		`resp $.user.ssn exists: "123-45-6789"`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONPathNotExists(mt, resp, "..ssn")
+		},
+		`resp $..ssn exists: ["123-45-6789"]`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONPathNotExists(mt, resp, "user[")
+		},
+		`resp invalid JSONPath "$.user[":`)
+}
+
+func TestAssertJSONPathMatches(t *testing.T) {
+	resp := `{"id": "usr_123", "age": 42}`
+	isString := func(v interface{}) bool {
+		_, ok := v.(string)
+		return ok
+	}
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONPathMatches(mt, resp, "id", isString)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONPathMatches(mt, resp, "age", isString)
+		},
+		`resp $.age (42) does not match`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONPathMatches(mt, resp, "name", isString)
+		},
+		`resp $.name: key "name" not found in object at $`)
+}
+
 func TestAssertContains(t *testing.T) {
 	t.Run("when input is string", func(t *testing.T) {
 		t.Run("when contains input", func(t *testing.T) {
@@ -549,11 +727,20 @@ func assert(t *testing.T, fn func(mt *mockTestingT) bool, want string) {
 
 type mockTestingT struct {
 	err, fatal string
+	cleanups   []func()
 }
 
+func (t *mockTestingT) Name() string              { return "TestMock" }
 func (t *mockTestingT) Helper()                   {}
 func (t *mockTestingT) Error(args ...interface{}) { t.err = fmt.Sprint(args...) }
 func (t *mockTestingT) Fatal(args ...interface{}) { t.fatal = fmt.Sprint(args...) }
+func (t *mockTestingT) Cleanup(fn func())         { t.cleanups = append(t.cleanups, fn) }
+
+func (t *mockTestingT) runCleanups() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
 
 func assertEQ(t *testing.T, got, want interface{}) {
 	t.Helper()
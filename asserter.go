@@ -0,0 +1,355 @@
+package assert
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Asserter makes assertions against the testingT it was created with. Its
+// methods mirror the package-level assertions, without the t parameter.
+type Asserter struct {
+	t      testingT
+	opts   []cmp.Option
+	prefix string
+	fatal  bool
+}
+
+// New returns an Asserter bound to t, which applies opts to every assertion in
+// addition to the registered default options. For example:
+//
+//     a := assert.New(t, cmpopts.EquateEmpty())
+//     a.Equal(got, want)
+//     a.JSONPath(resp, "user.id", 1)
+func New(t testingT, opts ...cmp.Option) *Asserter {
+	return &Asserter{t: t, opts: opts}
+}
+
+// WithOptions returns a copy of the Asserter which also applies opts.
+func (a *Asserter) WithOptions(opts ...cmp.Option) *Asserter {
+	b := *a
+	b.opts = append(append([]cmp.Option(nil), a.opts...), opts...)
+	return &b
+}
+
+// WithPrefix returns a copy of the Asserter which prefixes every failure
+// message with the formatted string.
+func (a *Asserter) WithPrefix(format string, args ...interface{}) *Asserter {
+	b := *a
+	b.prefix = fmt.Sprintf(format, args...)
+	if a.prefix != "" {
+		b.prefix = a.prefix + ": " + b.prefix
+	}
+	return &b
+}
+
+// FailFast returns a copy of the Asserter which stops the test with t.Fatal on
+// the first failure, rather than reporting it with t.Error.
+func (a *Asserter) FailFast() *Asserter {
+	b := *a
+	b.fatal = true
+	return &b
+}
+
+// T returns a testingT which makes assertions against it behave as if made
+// with the Asserter, for passing to the package-level assertions and helpers.
+func (a *Asserter) T() *Scoped {
+	return WithOptions(&asserterT{testingT: a.t, a: a}, a.opts...)
+}
+
+// Equal asserts that got and want are equal.
+func (a *Asserter) Equal(got, want interface{}, opts ...cmp.Option) bool {
+	a.t.Helper()
+	return Equal(a.T(), got, want, opts...)
+}
+
+// NotEqual asserts that got and want are not equal.
+func (a *Asserter) NotEqual(got, want interface{}, opts ...cmp.Option) bool {
+	a.t.Helper()
+	return NotEqual(a.T(), got, want, opts...)
+}
+
+// ErrorContains asserts that the error message contains the wanted string.
+func (a *Asserter) ErrorContains(got error, want string) bool {
+	a.t.Helper()
+	return ErrorContains(a.T(), got, want)
+}
+
+// ErrorIs asserts that err matches target, as reported by errors.Is.
+func (a *Asserter) ErrorIs(err, target error) bool {
+	a.t.Helper()
+	return ErrorIs(a.T(), err, target)
+}
+
+// NotErrorIs asserts that err does not match target, as reported by errors.Is.
+func (a *Asserter) NotErrorIs(err, target error) bool {
+	a.t.Helper()
+	return NotErrorIs(a.T(), err, target)
+}
+
+// ErrorAs asserts that err has an error in its chain that can be assigned to
+// target, as reported by errors.As.
+func (a *Asserter) ErrorAs(err error, target interface{}) bool {
+	a.t.Helper()
+	return ErrorAs(a.T(), err, target)
+}
+
+// ErrorChain asserts that every one of targets is found in the chain of err.
+func (a *Asserter) ErrorChain(err error, targets ...error) bool {
+	a.t.Helper()
+	return ErrorChain(a.T(), err, targets...)
+}
+
+// JSONEqual asserts that got and want are equal when represented as JSON.
+func (a *Asserter) JSONEqual(got, want interface{}, opts ...cmp.Option) bool {
+	a.t.Helper()
+	return JSONEqual(a.T(), got, want, opts...)
+}
+
+// JSONContains asserts that want is a subset of got when both are represented
+// as JSON.
+func (a *Asserter) JSONContains(got, want interface{}, opts ...cmp.Option) bool {
+	a.t.Helper()
+	return JSONContains(a.T(), got, want, opts...)
+}
+
+// JSONSchema asserts that the JSON representation of subject is valid
+// according to a JSON Schema.
+func (a *Asserter) JSONSchema(subject, schema interface{}) bool {
+	a.t.Helper()
+	return JSONSchema(a.T(), subject, schema)
+}
+
+// YAMLEqual asserts that got and want are equal when represented as YAML.
+func (a *Asserter) YAMLEqual(got, want interface{}, opts ...cmp.Option) bool {
+	a.t.Helper()
+	return YAMLEqual(a.T(), got, want, opts...)
+}
+
+// TOMLEqual asserts that got and want are equal when represented as TOML.
+func (a *Asserter) TOMLEqual(got, want interface{}, opts ...cmp.Option) bool {
+	a.t.Helper()
+	return TOMLEqual(a.T(), got, want, opts...)
+}
+
+// JSONPath asserts that evaluating the path expression against the subject
+// results in want.
+func (a *Asserter) JSONPath(subject interface{}, path string, want interface{}, opts ...cmp.Option) bool {
+	a.t.Helper()
+	return JSONPath(a.T(), subject, path, want, opts...)
+}
+
+// JSONLookup fetches a value from a JSON object using the path expression.
+func (a *Asserter) JSONLookup(subject interface{}, path string) interface{} {
+	a.t.Helper()
+	return JSONLookup(a.T(), subject, path)
+}
+
+// JSONPathExists asserts that evaluating the path expression against the
+// subject selects a value.
+func (a *Asserter) JSONPathExists(subject interface{}, path string) bool {
+	a.t.Helper()
+	return JSONPathExists(a.T(), subject, path)
+}
+
+// JSONPathNotExists asserts that evaluating the path expression against the
+// subject selects nothing.
+func (a *Asserter) JSONPathNotExists(subject interface{}, path string) bool {
+	a.t.Helper()
+	return JSONPathNotExists(a.T(), subject, path)
+}
+
+// JSONPathMatches asserts that evaluating the path expression against the
+// subject results in a value for which match returns true.
+func (a *Asserter) JSONPathMatches(subject interface{}, path string, match func(v interface{}) bool) bool {
+	a.t.Helper()
+	return JSONPathMatches(a.T(), subject, path, match)
+}
+
+// Contains asserts that got contains want.
+func (a *Asserter) Contains(got, want interface{}, opts ...cmp.Option) bool {
+	a.t.Helper()
+	return Contains(a.T(), got, want, opts...)
+}
+
+// ContainsAll asserts that got contains all items of want.
+func (a *Asserter) ContainsAll(got, want interface{}, opts ...cmp.Option) bool {
+	a.t.Helper()
+	return ContainsAll(a.T(), got, want, opts...)
+}
+
+// True asserts that got is true.
+func (a *Asserter) True(got bool) bool {
+	a.t.Helper()
+	return True(a.T(), got)
+}
+
+// False asserts that got is false.
+func (a *Asserter) False(got bool) bool {
+	a.t.Helper()
+	return False(a.T(), got)
+}
+
+// Match asserts that got matches the regex want.
+func (a *Asserter) Match(got, want string) bool {
+	a.t.Helper()
+	return Match(a.T(), got, want)
+}
+
+// Greater asserts that got is greater than want.
+func (a *Asserter) Greater(got, want interface{}) bool {
+	a.t.Helper()
+	return Greater(a.T(), got, want)
+}
+
+// GreaterOrEqual asserts that got is greater than or equal to want.
+func (a *Asserter) GreaterOrEqual(got, want interface{}) bool {
+	a.t.Helper()
+	return GreaterOrEqual(a.T(), got, want)
+}
+
+// Less asserts that got is less than want.
+func (a *Asserter) Less(got, want interface{}) bool {
+	a.t.Helper()
+	return Less(a.T(), got, want)
+}
+
+// LessOrEqual asserts that got is less than or equal to want.
+func (a *Asserter) LessOrEqual(got, want interface{}) bool {
+	a.t.Helper()
+	return LessOrEqual(a.T(), got, want)
+}
+
+// Between asserts that got is between lo and hi, inclusive.
+func (a *Asserter) Between(got, lo, hi interface{}) bool {
+	a.t.Helper()
+	return Between(a.T(), got, lo, hi)
+}
+
+// SortedBy asserts that the elements of the slice got are in ascending order
+// of the named field.
+func (a *Asserter) SortedBy(got interface{}, field string) bool {
+	a.t.Helper()
+	return SortedBy(a.T(), got, field)
+}
+
+// InDelta asserts that the numbers got and want differ by no more than delta.
+func (a *Asserter) InDelta(got, want interface{}, delta float64) bool {
+	a.t.Helper()
+	return InDelta(a.T(), got, want, delta)
+}
+
+// InEpsilon asserts that the relative error between the numbers got and want
+// is no more than epsilon.
+func (a *Asserter) InEpsilon(got, want interface{}, epsilon float64) bool {
+	a.t.Helper()
+	return InEpsilon(a.T(), got, want, epsilon)
+}
+
+// WithinDuration asserts that the times got and want differ by no more than
+// delta.
+func (a *Asserter) WithinDuration(got, want time.Time, delta time.Duration) bool {
+	a.t.Helper()
+	return WithinDuration(a.T(), got, want, delta)
+}
+
+// Must asserts that err is nil, calling t.Fatal otherwise.
+func (a *Asserter) Must(err error) {
+	a.t.Helper()
+	Must(a.T(), err)
+}
+
+// Nil asserts that got is nil.
+func (a *Asserter) Nil(got interface{}) bool {
+	a.t.Helper()
+	return Nil(a.T(), got)
+}
+
+// NotNil asserts that got is not nil.
+func (a *Asserter) NotNil(got interface{}) bool {
+	a.t.Helper()
+	return NotNil(a.T(), got)
+}
+
+// Empty asserts that got is empty.
+func (a *Asserter) Empty(got interface{}) bool {
+	a.t.Helper()
+	return Empty(a.T(), got)
+}
+
+// NotEmpty asserts that got is not empty.
+func (a *Asserter) NotEmpty(got interface{}) bool {
+	a.t.Helper()
+	return NotEmpty(a.T(), got)
+}
+
+// Panics asserts that fn panics.
+func (a *Asserter) Panics(fn func()) bool {
+	a.t.Helper()
+	return Panics(a.T(), fn)
+}
+
+// NotPanics asserts that fn does not panic.
+func (a *Asserter) NotPanics(fn func()) bool {
+	a.t.Helper()
+	return NotPanics(a.T(), fn)
+}
+
+// PanicsWithValue asserts that fn panics with a value equal to want.
+func (a *Asserter) PanicsWithValue(fn func(), want interface{}, opts ...cmp.Option) bool {
+	a.t.Helper()
+	return PanicsWithValue(a.T(), fn, want, opts...)
+}
+
+// PanicsWithError asserts that fn panics with an error whose message contains
+// the wanted string.
+func (a *Asserter) PanicsWithError(fn func(), want string) bool {
+	a.t.Helper()
+	return PanicsWithError(a.T(), fn, want)
+}
+
+// Eventually asserts that cond returns true within timeout, polling it every
+// interval.
+func (a *Asserter) Eventually(cond func() bool, timeout, interval time.Duration) bool {
+	a.t.Helper()
+	return Eventually(a.T(), cond, timeout, interval)
+}
+
+// Consistently asserts that cond keeps returning true for the whole duration,
+// polling it every interval.
+func (a *Asserter) Consistently(cond func() bool, duration, interval time.Duration) bool {
+	a.t.Helper()
+	return Consistently(a.T(), cond, duration, interval)
+}
+
+// asserterT is the testingT an Asserter passes to the package-level
+// assertions. It applies the Asserter's prefix and failure mode.
+type asserterT struct {
+	testingT
+	a *Asserter
+}
+
+func (t *asserterT) unwrap() testingT { return t.testingT }
+
+func (t *asserterT) Error(args ...interface{}) {
+	t.testingT.Helper()
+	if t.a.fatal {
+		t.testingT.Fatal(t.message(args))
+		return
+	}
+	t.testingT.Error(t.message(args))
+}
+
+func (t *asserterT) Fatal(args ...interface{}) {
+	t.testingT.Helper()
+	t.testingT.Fatal(t.message(args))
+}
+
+func (t *asserterT) message(args []interface{}) string {
+	msg := fmt.Sprint(args...)
+	if t.a.prefix != "" {
+		msg = t.a.prefix + ": " + msg
+	}
+	return msg
+}
@@ -0,0 +1,117 @@
+package assert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestAsserter(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		a := New(mt)
+		return a.Equal(1, 1)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			a := New(mt)
+			id := 1
+			return a.Equal(id, 2)
+		},
+		`id (-got +want):`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			out := "red orange yellow"
+			return New(mt).Contains(out, "blue")
+		},
+		`out ("red orange yellow") does not contain: "blue"`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			a := New(mt)
+			subject := map[string]interface{}{"id": 1}
+			return a.JSONPath(subject, "id", 2)
+		},
+		`subject $.id (-got +want):`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			orders := []struct{ Total int }{{2}, {1}}
+			return New(mt).SortedBy(orders, "Total")
+		},
+		`orders is not sorted by Total: orders[1].Total (1) is less than orders[0].Total (2)`)
+}
+
+func TestAsserterOptions(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	ignoreID := cmpopts.IgnoreFields(user{}, "ID")
+
+	assert(t, func(mt *mockTestingT) bool {
+		a := New(mt, ignoreID)
+		return a.Equal(user{ID: 1, Name: "Alice"}, user{ID: 2, Name: "Alice"})
+	}, ``)
+
+	assert(t, func(mt *mockTestingT) bool {
+		a := New(mt).WithOptions(ignoreID)
+		return a.ContainsAll([]user{{ID: 1, Name: "Alice"}}, []user{{ID: 2, Name: "Alice"}})
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			a := New(mt, ignoreID)
+			u := user{ID: 1, Name: "Alice"}
+			return a.Equal(u, user{ID: 2, Name: "Bob"})
+		},
+		`u (-got +want):`)
+}
+
+func TestAsserterPrefix(t *testing.T) {
+	assert(t,
+		func(mt *mockTestingT) bool {
+			a := New(mt).WithPrefix("order %d", 1)
+			total := 10
+			return a.Equal(total, 20)
+		},
+		`order 1: total (-got +want):`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			a := New(mt).WithPrefix("order %d", 1).WithPrefix("item %d", 2)
+			var val string
+			return a.NotEmpty(val)
+		},
+		`order 1: item 2: val was empty`)
+}
+
+func TestAsserterFailFast(t *testing.T) {
+	mt := &mockTestingT{}
+	a := New(mt).FailFast()
+	enabled := false
+	a.True(enabled)
+	assertEQ(t, mt.err, "")
+	assertEQ(t, mt.fatal[:len("enabled (-got +want):")], "enabled (-got +want):")
+
+	mt = &mockTestingT{}
+	New(mt).WithPrefix("setup").Must(errors.New("boom"))
+	assertEQ(t, mt.fatal, "setup: boom")
+}
+
+func TestAsserterT(t *testing.T) {
+	assert(t,
+		func(mt *mockTestingT) bool {
+			a := New(mt).WithPrefix("order %d", 1)
+			total := 10
+			return Equal(a.T(), total, 20)
+		},
+		`order 1: total (-got +want):`)
+
+	mt := &mockTestingT{}
+	True(New(mt).FailFast().T(), false)
+	assertEQ(t, mt.err, "")
+	assertEQ(t, mt.fatal != "", true)
+}
@@ -0,0 +1,134 @@
+package assert
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// cleanupT is a testingT that can register functions to run when the test
+// completes.
+type cleanupT interface {
+	testingT
+	Cleanup(func())
+}
+
+// Collector is a testingT that buffers assertion failures instead of reporting
+// them immediately. It can be passed in place of t to any assertion.
+type Collector struct {
+	t cleanupT
+
+	mu       sync.Mutex
+	failures []string
+}
+
+// Collect returns a Collector wrapping t. Failures from assertions made
+// against the Collector are reported together as a single numbered list when
+// the test ends, or earlier if Report or Require is called. For example:
+//
+//     c := assert.Collect(t)
+//     for _, tt := range tests {
+//         assert.Equal(c, tt.got, tt.want)
+//     }
+func Collect(t cleanupT) *Collector {
+	c := &Collector{t: t}
+	t.Cleanup(c.Report)
+	return c
+}
+
+func (c *Collector) unwrap() testingT { return c.t }
+
+// Helper implements testingT.
+func (c *Collector) Helper() {}
+
+// Error records a failure to be reported later.
+func (c *Collector) Error(args ...interface{}) {
+	c.add(fmt.Sprint(args...))
+}
+
+// Fatal records a failure, reports all failures collected so far and stops the
+// test.
+func (c *Collector) Fatal(args ...interface{}) {
+	c.t.Helper()
+	c.add(fmt.Sprint(args...))
+	c.t.Fatal(c.flush())
+}
+
+// Failed reports whether any failures have been collected and not yet
+// reported.
+func (c *Collector) Failed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.failures) > 0
+}
+
+// Report reports all failures collected so far as a single error. It is called
+// automatically when the test ends.
+func (c *Collector) Report() {
+	c.t.Helper()
+	if msg := c.flush(); msg != "" {
+		c.t.Error(msg)
+	}
+}
+
+// Require reports all failures collected so far and, if there were any, stops
+// the test.
+func (c *Collector) Require() {
+	c.t.Helper()
+	if msg := c.flush(); msg != "" {
+		c.t.Fatal(msg)
+	}
+}
+
+func (c *Collector) add(msg string) {
+	if file, line, ok := callSite(); ok {
+		msg = fmt.Sprintf("%s:%d: %s", filepath.Base(file), line, msg)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = append(c.failures, msg)
+}
+
+// flush returns the collected failures formatted as a numbered list, and
+// clears them.
+func (c *Collector) flush() string {
+	c.mu.Lock()
+	failures := c.failures
+	c.failures = nil
+	c.mu.Unlock()
+
+	if len(failures) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	if len(failures) == 1 {
+		b.WriteString("1 assertion failed:")
+	} else {
+		fmt.Fprintf(&b, "%d assertions failed:", len(failures))
+	}
+	for i, f := range failures {
+		prefix := fmt.Sprintf("%d. ", i+1)
+		indent := strings.Repeat(" ", len(prefix))
+		b.WriteString("\n" + prefix + strings.Replace(f, "\n", "\n"+indent, -1))
+	}
+	return b.String()
+}
+
+// callSite returns the location of the first caller outside of this package
+// (and its subpackages).
+func callSite() (file string, line int, ok bool) {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !isLibFrame(frame) {
+			return frame.File, frame.Line, frame.File != ""
+		}
+		if !more {
+			return "", 0, false
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package assert
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCollect(t *testing.T) {
+	t.Run("no failures", func(t *testing.T) {
+		mt := &mockTestingT{}
+		c := Collect(mt)
+		Equal(c, 1, 1)
+		assertEQ(t, c.Failed(), false)
+		mt.runCleanups()
+		assertEQ(t, mt.err, "")
+	})
+
+	t.Run("reports failures on cleanup", func(t *testing.T) {
+		mt := &mockTestingT{}
+		c := Collect(mt)
+		id := 1
+		Equal(c, id, 2)
+		out := "red"
+		Contains(c, out, "blue")
+		assertEQ(t, mt.err, "")
+		assertEQ(t, c.Failed(), true)
+
+		mt.runCleanups()
+		lines := strings.Split(mt.err, "\n")
+		assertEQ(t, lines[0], "2 assertions failed:")
+		assertEQ(t, strings.HasPrefix(lines[1], "1. collect_test.go:"), true)
+		assertEQ(t, strings.Contains(lines[1], "id (-got +want):"), true)
+		assertEQ(t, strings.HasPrefix(lines[len(lines)-1], "2. collect_test.go:"), true)
+		assertEQ(t, strings.HasSuffix(mt.err, `out ("red") does not contain: "blue"`), true)
+	})
+
+	t.Run("indents multi-line failures", func(t *testing.T) {
+		mt := &mockTestingT{}
+		c := Collect(mt)
+		c.Error("first\nsecond")
+		c.Report()
+		assertEQ(t, strings.HasSuffix(mt.err, ": first\n   second"), true)
+	})
+
+	t.Run("require", func(t *testing.T) {
+		mt := &mockTestingT{}
+		c := Collect(mt)
+		c.Require()
+		assertEQ(t, mt.fatal, "")
+
+		True(c, false)
+		c.Require()
+		assertEQ(t, strings.HasPrefix(mt.fatal, "1 assertion failed:\n1. collect_test.go:"), true)
+		assertEQ(t, c.Failed(), false)
+
+		mt.runCleanups()
+		assertEQ(t, mt.err, "")
+	})
+
+	t.Run("fatal", func(t *testing.T) {
+		mt := &mockTestingT{}
+		c := Collect(mt)
+		Equal(c, 1, 2)
+		Must(c, errors.New("boom"))
+		assertEQ(t, strings.HasPrefix(mt.fatal, "2 assertions failed:"), true)
+		assertEQ(t, strings.HasSuffix(mt.fatal, ": boom"), true)
+	})
+}
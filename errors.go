@@ -0,0 +1,159 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrorIs asserts that err matches target, as reported by errors.Is. Errors
+// wrapping multiple errors (e.g. from errors.Join) are searched too.
+func ErrorIs(t testingT, err, target error) bool {
+	t.Helper()
+	if err == nil {
+		t.Error(formatError(getArg(1)(), "was nil"))
+		return false
+	}
+	if !errorIs(err, target) {
+		msg := fmt.Sprintf("does not match %s\n%s", describeError(target), formatChain(err))
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	return true
+}
+
+// NotErrorIs asserts that err does not match target, as reported by errors.Is.
+// Errors wrapping multiple errors are searched too.
+func NotErrorIs(t testingT, err, target error) bool {
+	t.Helper()
+	if errorIs(err, target) {
+		msg := fmt.Sprintf("matches %s\n%s", describeError(target), formatChain(err))
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	return true
+}
+
+// ErrorAs asserts that err has an error in its chain that can be assigned to
+// target, as reported by errors.As. If it does, target is set to that error.
+// The target parameter must be a non-nil pointer to an error type or
+// interface. Errors wrapping multiple errors are searched too.
+func ErrorAs(t testingT, err error, target interface{}) (ok bool) {
+	t.Helper()
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		t.Error("target must be a non-nil pointer")
+		return false
+	}
+	if err == nil {
+		t.Error(formatError(getArg(1)(), "was nil"))
+		return false
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			t.Error(r)
+			ok = false
+		}
+	}()
+	if !errorAs(err, target) {
+		msg := fmt.Sprintf("has no error assignable to %s\n%s", v.Type().Elem(), formatChain(err))
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	return true
+}
+
+// ErrorChain asserts that every one of targets is found in the chain of err,
+// as reported by errors.Is. Errors wrapping multiple errors are searched too.
+func ErrorChain(t testingT, err error, targets ...error) bool {
+	t.Helper()
+	if err == nil {
+		t.Error(formatError(getArg(1)(), "was nil"))
+		return false
+	}
+	var missing []string
+	for _, target := range targets {
+		if !errorIs(err, target) {
+			missing = append(missing, describeError(target))
+		}
+	}
+	if len(missing) > 0 {
+		msg := fmt.Sprintf("does not match %s\n%s", strings.Join(missing, ", "), formatChain(err))
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	return true
+}
+
+// errorIs is like errors.Is, but also searches the errors wrapped by errors with
+// an Unwrap() []error method (e.g. from errors.Join), which errors.Is only does
+// from Go 1.20.
+func errorIs(err, target error) bool {
+	if errors.Is(err, target) {
+		return true
+	}
+	return anyWrapped(err, func(e error) bool { return errorIs(e, target) })
+}
+
+// errorAs is like errors.As, but also searches the errors wrapped by errors
+// with an Unwrap() []error method.
+func errorAs(err error, target interface{}) bool {
+	if errors.As(err, target) {
+		return true
+	}
+	return anyWrapped(err, func(e error) bool { return errorAs(e, target) })
+}
+
+// anyWrapped reports whether fn returns true for any error wrapped by an error
+// with an Unwrap() []error method in the chain of err.
+func anyWrapped(err error, fn func(error) bool) bool {
+	for err != nil {
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range x.Unwrap() {
+				if fn(e) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// formatChain renders the unwrap chain of err, one error per line, with each
+// wrapped error indented beneath the error wrapping it. Errors wrapping
+// multiple errors (e.g. from errors.Join) have each of them at the same depth.
+func formatChain(err error) string {
+	var b strings.Builder
+	b.WriteString("error chain:")
+	writeChain(&b, err, 1)
+	return b.String()
+}
+
+func writeChain(b *strings.Builder, err error, depth int) {
+	if err == nil {
+		return
+	}
+	fmt.Fprintf(b, "\n%s%s", strings.Repeat("  ", depth), describeError(err))
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		writeChain(b, x.Unwrap(), depth+1)
+	case interface{ Unwrap() []error }:
+		for _, e := range x.Unwrap() {
+			writeChain(b, e, depth+1)
+		}
+	}
+}
+
+func describeError(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%T(%q)", err, err.Error())
+}
@@ -0,0 +1,129 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errNotFound = errors.New("not found")
+
+type codeError struct{ Code int }
+
+func (e *codeError) Error() string { return fmt.Sprintf("code %d", e.Code) }
+
+type multiError []error
+
+func (e multiError) Error() string   { return "multiple errors" }
+func (e multiError) Unwrap() []error { return e }
+
+func TestErrorIs(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		err := fmt.Errorf("load: %w", errNotFound)
+		return ErrorIs(mt, err, errNotFound)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			err := fmt.Errorf("load: %w", errors.New("timeout"))
+			return ErrorIs(mt, err, errNotFound)
+		},
+		removeLeadingTabs(`err does not match *errors.errorString("not found")
+		error chain:
+		  *fmt.wrapError("load: timeout")
+		    *errors.errorString("timeout")`))
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			var err error
+			return ErrorIs(mt, err, errNotFound)
+		},
+		`err was nil`)
+}
+
+func TestNotErrorIs(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		err := errors.New("timeout")
+		return NotErrorIs(mt, err, errNotFound)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			err := fmt.Errorf("load: %w", errNotFound)
+			return NotErrorIs(mt, err, errNotFound)
+		},
+		`err matches *errors.errorString("not found")`)
+}
+
+func TestErrorAs(t *testing.T) {
+	t.Run("match", func(t *testing.T) {
+		var target *codeError
+		assert(t, func(mt *mockTestingT) bool {
+			err := fmt.Errorf("load: %w", &codeError{Code: 404})
+			return ErrorAs(mt, err, &target)
+		}, ``)
+		assertEQ(t, target.Code, 404)
+	})
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			var target *codeError
+			err := fmt.Errorf("load: %w", errNotFound)
+			return ErrorAs(mt, err, &target)
+		},
+		`err has no error assignable to *assert.codeError`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			err := fmt.Errorf("load: %w", errNotFound)
+			return ErrorAs(mt, err, nil)
+		},
+		`target must be a non-nil pointer`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			var target string
+			err := fmt.Errorf("load: %w", errNotFound)
+			return ErrorAs(mt, err, &target)
+		},
+		`errors: *target must be interface or implement error`)
+}
+
+func TestErrorChain(t *testing.T) {
+	errTimeout := errors.New("timeout")
+	err := fmt.Errorf("sync: %w", multiError{errNotFound, fmt.Errorf("retry: %w", errTimeout)})
+
+	assert(t, func(mt *mockTestingT) bool {
+		return ErrorChain(mt, err, errNotFound, errTimeout)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return ErrorChain(mt, err, errNotFound, errors.New("closed"))
+		},
+		removeLeadingTabs(`err does not match *errors.errorString("closed")
+		error chain:
+		  *fmt.wrapError("sync: multiple errors")
+		    assert.multiError("multiple errors")
+		      *errors.errorString("not found")
+		      *fmt.wrapError("retry: timeout")
+		        *errors.errorString("timeout")`))
+}
+
+func TestErrorsWrappingMultipleErrors(t *testing.T) {
+	// errors.Is and errors.As only search errors with an Unwrap() []error
+	// method from Go 1.20, so the assertions search them themselves.
+	err := fmt.Errorf("sync: %w", multiError{errors.New("closed"), &codeError{Code: 404}})
+
+	assert(t, func(mt *mockTestingT) bool { return ErrorIs(mt, err, errNotFound) }, `err does not match`)
+	assert(t, func(mt *mockTestingT) bool {
+		return ErrorIs(mt, multiError{errNotFound}, errNotFound)
+	}, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		return NotErrorIs(mt, multiError{errNotFound}, errNotFound)
+	}, `multiError{errNotFound} matches`)
+	assert(t, func(mt *mockTestingT) bool {
+		var target *codeError
+		return ErrorAs(mt, err, &target) && target.Code == 404
+	}, ``)
+}
@@ -0,0 +1,103 @@
+package assert
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Eventually asserts that cond returns true within timeout, polling it every
+// interval.
+func Eventually(t testingT, cond func() bool, timeout, interval time.Duration) bool {
+	t.Helper()
+	if !validInterval(t, interval) {
+		return false
+	}
+	if poll(timeout, interval, cond) {
+		return true
+	}
+	msg := fmt.Sprintf("was not true within %v", timeout)
+	t.Error(formatError(getArg(1)(), msg))
+	return false
+}
+
+// Consistently asserts that cond keeps returning true for the whole duration,
+// polling it every interval.
+func Consistently(t testingT, cond func() bool, duration, interval time.Duration) bool {
+	t.Helper()
+	if !validInterval(t, interval) {
+		return false
+	}
+	start := time.Now()
+	if !poll(duration, interval, func() bool { return !cond() }) {
+		return true
+	}
+	msg := fmt.Sprintf("was not true after %v", time.Since(start).Round(time.Millisecond))
+	t.Error(formatError(getArg(1)(), msg))
+	return false
+}
+
+// EventuallyEqual asserts that fn returns a value equal to want within timeout,
+// polling it every interval. If the deadline expires, the diff between the last
+// value returned and want is reported.
+func EventuallyEqual[T any](t testingT, fn func() T, want T, timeout, interval time.Duration, opts ...cmp.Option) bool {
+	t.Helper()
+	t, opts = annotate(t, opts)
+	if !validInterval(t, interval) {
+		return false
+	}
+	var got T
+	if poll(timeout, interval, func() bool {
+		got = fn()
+		return isEqual(t, got, want, opts)
+	}) {
+		return true
+	}
+	expr := getArg(1)
+	return assertEqual(t, func() string {
+		return formatError(expr(), fmt.Sprintf("(after %v)", timeout))
+	}, got, want, opts)
+}
+
+// validInterval reports whether interval is usable for polling, reporting an
+// error if not.
+func validInterval(t testingT, interval time.Duration) bool {
+	t.Helper()
+	if interval <= 0 {
+		t.Error(fmt.Sprintf("interval must be positive, got %v", interval))
+		return false
+	}
+	return true
+}
+
+// poll calls cond every interval until it returns true or timeout elapses,
+// returning whether cond was satisfied. cond is always called at least once.
+func poll(timeout, interval time.Duration, cond func() bool) bool {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if cond() {
+			return true
+		}
+		select {
+		case <-deadline.C:
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// isEqual reports whether got and want are equal, treating a panic from cmp as
+// inequality.
+func isEqual(t testingT, got, want interface{}, opts []cmp.Option) (eq bool) {
+	defer func() {
+		if err := recover(); err != nil {
+			eq = false
+		}
+	}()
+	opts = withDefaults(t, opts)
+	return cmp.Equal(got, want, opts...)
+}
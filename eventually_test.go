@@ -0,0 +1,65 @@
+package assert
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventually(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		var n int32
+		ready := func() bool { return atomic.AddInt32(&n, 1) > 3 }
+		return Eventually(mt, ready, time.Second, time.Millisecond)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			ready := func() bool { return false }
+			return Eventually(mt, ready, 10*time.Millisecond, time.Millisecond)
+		},
+		`ready was not true within 10ms`)
+}
+
+func TestConsistently(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		ok := func() bool { return true }
+		return Consistently(mt, ok, 10*time.Millisecond, time.Millisecond)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			var n int32
+			ok := func() bool { return atomic.AddInt32(&n, 1) < 3 }
+			return Consistently(mt, ok, time.Second, time.Millisecond)
+		},
+		`ok was not true after `)
+}
+
+func TestEventuallyEqual(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		var n int32
+		count := func() int32 { return atomic.AddInt32(&n, 1) }
+		return EventuallyEqual(mt, count, 3, time.Second, time.Millisecond)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			status := func() string { return "pending" }
+			return EventuallyEqual(mt, status, "done", 10*time.Millisecond, time.Millisecond)
+		},
+		`status (after 10ms) (-got +want):`)
+}
+
+func TestPollInterval(t *testing.T) {
+	ready := func() bool { return true }
+	assert(t, func(mt *mockTestingT) bool {
+		return Eventually(mt, ready, time.Second, 0)
+	}, `interval must be positive, got 0s`)
+	assert(t, func(mt *mockTestingT) bool {
+		return Consistently(mt, ready, time.Second, -time.Millisecond)
+	}, `interval must be positive, got -1ms`)
+	assert(t, func(mt *mockTestingT) bool {
+		return EventuallyEqual(mt, func() int { return 1 }, 1, time.Second, 0)
+	}, `interval must be positive, got 0s`)
+}
@@ -1,8 +1,12 @@
 module github.com/deliveroo/assert-go
 
-go 1.12
+go 1.18
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/google/go-cmp v0.5.5
-	github.com/oliveagle/jsonpath v0.0.0-20180606110733-2e52cf6e6852
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.0
+	gopkg.in/yaml.v3 v3.0.1
 )
+
+require golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543 // indirect
@@ -0,0 +1,141 @@
+package assert
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// update is set by running "go test -assert.update", and causes Golden to
+// rewrite the golden files rather than comparing against them. The flag is
+// namespaced so as not to clash with an -update flag defined by the package
+// under test.
+var update = flag.Bool("assert.update", false, "update golden files")
+
+// goldenDir is the directory golden files are stored in, relative to the
+// package under test.
+var goldenDir = "testdata"
+
+// namedT is a testingT that knows the name of the running test.
+type namedT interface {
+	testingT
+	Name() string
+}
+
+// Golden asserts that got matches the contents of the golden file
+// testdata/<TestName>/<name>.golden. Strings and byte slices are compared
+// as-is; any other value is normalised to JSON (as in JSONEqual) and
+// indented before comparison.
+//
+// Running the tests with "go test -assert.update" or ASSERT_UPDATE=1 rewrites
+// the golden files with the current values instead of failing.
+func Golden(t namedT, got interface{}, name string) bool {
+	t.Helper()
+	b, err := goldenBytes(got)
+	if err != nil {
+		t.Error(formatError(getArg(1)(), err.Error()))
+		return false
+	}
+	path := goldenPath(t, name)
+	if updateGolden() {
+		if err := writeGolden(path, b); err != nil {
+			t.Error(err)
+			return false
+		}
+		return true
+	}
+	want, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		msg := fmt.Sprintf("golden file %s does not exist (run with -assert.update to create it)", path)
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	} else if err != nil {
+		t.Error(err)
+		return false
+	}
+	return assertEqual(t, getArg(1), string(b), string(want), nil)
+}
+
+// GoldenJSON asserts that got is equal to the contents of the golden file
+// testdata/<TestName>/<name>.golden when both are represented as JSON. Unlike
+// Golden, formatting and key order in the golden file are not significant.
+func GoldenJSON(t namedT, got interface{}, name string, opts ...cmp.Option) bool {
+	t.Helper()
+	path := goldenPath(t, name)
+	at, opts := annotate(t, opts)
+	got, ok := decodeJSON(at, 1, got)
+	if !ok {
+		return false
+	}
+	if updateGolden() {
+		b, err := indentJSON(got)
+		if err != nil {
+			at.Error(formatError(getArg(1)(), err.Error()))
+			return false
+		}
+		if err := writeGolden(path, b); err != nil {
+			at.Error(err)
+			return false
+		}
+		return true
+	}
+	want, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		msg := fmt.Sprintf("golden file %s does not exist (run with -assert.update to create it)", path)
+		at.Error(formatError(getArg(1)(), msg))
+		return false
+	} else if err != nil {
+		at.Error(err)
+		return false
+	}
+	wantJSON, err := toJSON(want)
+	if err != nil {
+		at.Error(fmt.Sprintf("golden file %s %v", path, err))
+		return false
+	}
+	return assertJSONEqual(at, getArg(1), got, wantJSON, opts)
+}
+
+// goldenBytes serializes v for storage in a golden file.
+func goldenBytes(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	}
+	j, err := toJSON(v)
+	if err != nil {
+		return nil, err
+	}
+	return indentJSON(j)
+}
+
+// indentJSON marshals v as indented JSON, followed by a newline.
+func indentJSON(v interface{}) ([]byte, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+func goldenPath(t namedT, name string) string {
+	return filepath.Join(goldenDir, filepath.FromSlash(t.Name()), name+".golden")
+}
+
+func updateGolden() bool {
+	return *update || os.Getenv("ASSERT_UPDATE") == "1"
+}
+
+func writeGolden(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
@@ -0,0 +1,112 @@
+package assert
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withGoldenDir points goldenDir at a temporary directory, returning it and a
+// function which removes it and restores goldenDir.
+func withGoldenDir(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := goldenDir
+	goldenDir = dir
+	return dir, func() {
+		goldenDir = prev
+		os.RemoveAll(dir)
+	}
+}
+
+func writeGoldenFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := writeGolden(filepath.Join(dir, "TestMock", name+".golden"), []byte(content)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	dir, restore := withGoldenDir(t)
+	defer restore()
+	writeGoldenFile(t, dir, "greeting", "hello\nworld\n")
+	writeGoldenFile(t, dir, "user", "{\n  \"id\": 1\n}\n")
+
+	assert(t, func(mt *mockTestingT) bool {
+		return Golden(mt, "hello\nworld\n", "greeting")
+	}, ``)
+
+	assert(t, func(mt *mockTestingT) bool {
+		return Golden(mt, []byte("hello\nworld\n"), "greeting")
+	}, ``)
+
+	assert(t, func(mt *mockTestingT) bool {
+		user := struct {
+			ID int `json:"id"`
+		}{1}
+		return Golden(mt, user, "user")
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			out := "hello\nthere\n"
+			return Golden(mt, out, "greeting")
+		},
+		`out (-got +want):`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			out := "hello"
+			return Golden(mt, out, "missing")
+		},
+		`out golden file `+filepath.Join(dir, "TestMock", "missing.golden")+` does not exist`)
+}
+
+func TestGoldenUpdate(t *testing.T) {
+	dir, restore := withGoldenDir(t)
+	defer restore()
+	prev, ok := os.LookupEnv("ASSERT_UPDATE")
+	os.Setenv("ASSERT_UPDATE", "1")
+	defer func() {
+		if ok {
+			os.Setenv("ASSERT_UPDATE", prev)
+		} else {
+			os.Unsetenv("ASSERT_UPDATE")
+		}
+	}()
+
+	assert(t, func(mt *mockTestingT) bool {
+		return Golden(mt, map[string]int{"id": 1}, "user")
+	}, ``)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "TestMock", "user.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEQ(t, string(b), "{\n  \"id\": 1\n}\n")
+}
+
+func TestGoldenJSON(t *testing.T) {
+	dir, restore := withGoldenDir(t)
+	defer restore()
+	writeGoldenFile(t, dir, "user", `{"name": "Alice", "id": 1}`)
+
+	assert(t, func(mt *mockTestingT) bool {
+		user := struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}{1, "Alice"}
+		return GoldenJSON(mt, user, "user")
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			user := map[string]interface{}{"id": 2, "name": "Alice"}
+			return GoldenJSON(mt, user, "user")
+		},
+		`user (-got +want):`)
+}
@@ -0,0 +1,285 @@
+package jsonpath
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+	"unicode/utf8"
+)
+
+// expr is an operand or logical expression within a filter selector: one of
+// literalExpr, queryExpr, *funcExpr or logicalExpr.
+type expr interface{}
+
+// logicalExpr is an expression evaluating to true or false.
+type logicalExpr interface {
+	// eval evaluates the expression, with cur as the current node ("@").
+	eval(root, cur interface{}) bool
+}
+
+type orExpr []logicalExpr
+
+func (e orExpr) eval(root, cur interface{}) bool {
+	for _, x := range e {
+		if x.eval(root, cur) {
+			return true
+		}
+	}
+	return false
+}
+
+type andExpr []logicalExpr
+
+func (e andExpr) eval(root, cur interface{}) bool {
+	for _, x := range e {
+		if !x.eval(root, cur) {
+			return false
+		}
+	}
+	return true
+}
+
+type notExpr struct {
+	x logicalExpr
+}
+
+func (e notExpr) eval(root, cur interface{}) bool {
+	return !e.x.eval(root, cur)
+}
+
+// existsExpr tests whether a query selects any nodes.
+type existsExpr struct {
+	query queryExpr
+}
+
+func (e existsExpr) eval(root, cur interface{}) bool {
+	return len(e.query.nodes(root, cur)) > 0
+}
+
+type comparisonExpr struct {
+	left  expr
+	op    string
+	right expr
+}
+
+func (e comparisonExpr) eval(root, cur interface{}) bool {
+	l, r := evalValue(e.left, root, cur), evalValue(e.right, root, cur)
+	switch e.op {
+	case "==":
+		return equal(l, r)
+	case "!=":
+		return !equal(l, r)
+	case "<":
+		return less(l, r)
+	case "<=":
+		return less(l, r) || equal(l, r)
+	case ">":
+		return less(r, l)
+	case ">=":
+		return less(r, l) || equal(l, r)
+	}
+	return false
+}
+
+type literalExpr struct {
+	v interface{}
+}
+
+type queryExpr struct {
+	relative bool
+	path     *Path
+}
+
+func (e queryExpr) nodes(root, cur interface{}) []interface{} {
+	if e.relative {
+		return e.path.selectFrom(root, cur)
+	}
+	return e.path.selectFrom(root, root)
+}
+
+// nothing represents the absence of a value, e.g. the result of a singular
+// query which selects no nodes.
+type nothing struct{}
+
+// evalValue evaluates an operand to a single value, or nothing.
+func evalValue(e expr, root, cur interface{}) interface{} {
+	switch x := e.(type) {
+	case literalExpr:
+		return x.v
+	case queryExpr:
+		if nodes := x.nodes(root, cur); len(nodes) == 1 {
+			return nodes[0]
+		}
+	case *funcExpr:
+		return x.call(root, cur)
+	}
+	return nothing{}
+}
+
+func equal(x, y interface{}) bool {
+	switch x := x.(type) {
+	case float64:
+		y, ok := y.(float64)
+		return ok && x == y
+	case []interface{}:
+		y, ok := y.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		y, ok := y.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func less(x, y interface{}) bool {
+	switch x := x.(type) {
+	case float64:
+		y, ok := y.(float64)
+		return ok && x < y
+	case string:
+		y, ok := y.(string)
+		return ok && x < y
+	}
+	return false
+}
+
+// argType is the type of a function parameter or result.
+type argType int
+
+const (
+	valueType argType = iota
+	logicalType
+	nodesType
+)
+
+// function is a function extension available in filter expressions.
+type function struct {
+	name   string
+	params []argType
+	result argType
+
+	// fn implements the function. Its arguments are a value (or nothing),
+	// a bool, or a []interface{} of nodes according to params, and it returns
+	// a value (or nothing) or a bool according to result.
+	fn func(args []interface{}) interface{}
+}
+
+var functions = map[string]*function{}
+
+func init() {
+	for _, fn := range []*function{
+		{name: "length", params: []argType{valueType}, result: valueType, fn: length},
+		{name: "count", params: []argType{nodesType}, result: valueType, fn: count},
+		{name: "match", params: []argType{valueType, valueType}, result: logicalType, fn: match},
+		{name: "search", params: []argType{valueType, valueType}, result: logicalType, fn: search},
+		{name: "value", params: []argType{nodesType}, result: valueType, fn: value},
+	} {
+		functions[fn.name] = fn
+	}
+}
+
+type funcExpr struct {
+	fn   *function
+	args []expr
+}
+
+// call evaluates the arguments and calls the function.
+func (e *funcExpr) call(root, cur interface{}) interface{} {
+	args := make([]interface{}, len(e.args))
+	for i, arg := range e.args {
+		switch e.fn.params[i] {
+		case valueType:
+			args[i] = evalValue(arg, root, cur)
+		case nodesType:
+			args[i] = arg.(queryExpr).nodes(root, cur)
+		case logicalType:
+			args[i] = evalLogical(arg, root, cur)
+		}
+	}
+	return e.fn.fn(args)
+}
+
+func (e *funcExpr) eval(root, cur interface{}) bool {
+	b, _ := e.call(root, cur).(bool)
+	return b
+}
+
+func evalLogical(e expr, root, cur interface{}) bool {
+	switch x := e.(type) {
+	case queryExpr:
+		return len(x.nodes(root, cur)) > 0
+	case logicalExpr:
+		return x.eval(root, cur)
+	}
+	return false
+}
+
+func length(args []interface{}) interface{} {
+	switch v := args[0].(type) {
+	case string:
+		return float64(utf8.RuneCountInString(v))
+	case []interface{}:
+		return float64(len(v))
+	case map[string]interface{}:
+		return float64(len(v))
+	}
+	return nothing{}
+}
+
+func count(args []interface{}) interface{} {
+	return float64(len(args[0].([]interface{})))
+}
+
+func value(args []interface{}) interface{} {
+	if nodes := args[0].([]interface{}); len(nodes) == 1 {
+		return nodes[0]
+	}
+	return nothing{}
+}
+
+func match(args []interface{}) interface{} {
+	return matchRegexp(args, `^(?:%s)$`)
+}
+
+func search(args []interface{}) interface{} {
+	return matchRegexp(args, `%s`)
+}
+
+// regexps caches compiled regular expressions, keyed by their source.
+var regexps sync.Map
+
+func matchRegexp(args []interface{}, format string) bool {
+	s, ok := args[0].(string)
+	pattern, ok2 := args[1].(string)
+	if !ok || !ok2 {
+		return false
+	}
+	key := format + "\x00" + pattern
+	re, ok := regexps.Load(key)
+	if !ok {
+		compiled, err := regexp.Compile(fmt.Sprintf(format, pattern))
+		if err != nil {
+			return false
+		}
+		re, _ = regexps.LoadOrStore(key, compiled)
+	}
+	return re.(*regexp.Regexp).MatchString(s)
+}
@@ -0,0 +1,398 @@
+// Package jsonpath implements JSONPath queries, as specified by RFC 9535,
+// against decoded JSON values (as produced by encoding/json when unmarshaling
+// into an interface{}).
+package jsonpath
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Path is a compiled JSONPath query.
+type Path struct {
+	src      string
+	segments []*segment
+}
+
+// segment is a single child (e.g. ".name" or "[0, 1]") or descendant (e.g.
+// "..name") segment of a query.
+type segment struct {
+	src        string
+	descendant bool
+	selectors  []selector
+}
+
+// selector selects nodes from a single input node.
+type selector interface {
+	// apply appends the nodes selected from v to nodes. The root value is
+	// needed to evaluate absolute queries within filters.
+	apply(root, v interface{}, nodes []interface{}) []interface{}
+
+	// matches reports whether the selector selects the child of v reached by
+	// step.
+	matches(root, v interface{}, step Step) bool
+}
+
+// Parse compiles a JSONPath query, which must begin with "$".
+func Parse(path string) (*Path, error) {
+	p := &parser{src: path}
+	q, err := p.parseQuery('$')
+	if err != nil {
+		return nil, err
+	}
+	if !p.eof() {
+		return nil, p.errorf("unexpected %s", p.describe())
+	}
+	return q, nil
+}
+
+// MustParse is like Parse, but panics if the query can't be parsed.
+func MustParse(path string) *Path {
+	p, err := Parse(path)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the source of the query.
+func (p *Path) String() string {
+	return p.src
+}
+
+// Singular reports whether the query can select at most one node, i.e. it
+// consists only of name and index selectors.
+func (p *Path) Singular() bool {
+	for _, seg := range p.segments {
+		if !seg.singular() {
+			return false
+		}
+	}
+	return true
+}
+
+// Select returns the nodes selected by the query from root, in document order.
+// Object members are visited in order of their keys.
+func (p *Path) Select(root interface{}) []interface{} {
+	return p.selectFrom(root, root)
+}
+
+func (p *Path) selectFrom(root, v interface{}) []interface{} {
+	nodes := []interface{}{v}
+	for _, seg := range p.segments {
+		var next []interface{}
+		for _, n := range nodes {
+			next = seg.apply(root, n, next)
+		}
+		nodes = next
+	}
+	if nodes == nil {
+		nodes = []interface{}{}
+	}
+	return nodes
+}
+
+// Get evaluates the query against root. For a singular query, it returns the
+// selected value, or a *NotFoundError if there is none. Otherwise it returns the
+// selected nodes as a []interface{}, which may be empty.
+func (p *Path) Get(root interface{}) (interface{}, error) {
+	if !p.Singular() {
+		return p.Select(root), nil
+	}
+	v := root
+	for i, seg := range p.segments {
+		nodes := seg.apply(root, v, nil)
+		if len(nodes) == 0 {
+			return nil, p.notFound(i, v)
+		}
+		v = nodes[0]
+	}
+	return v, nil
+}
+
+// Lookup compiles path and evaluates it against root, as in Path.Get.
+func Lookup(root interface{}, path string) (interface{}, error) {
+	p, err := Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	return p.Get(root)
+}
+
+func (s *segment) singular() bool {
+	if s.descendant || len(s.selectors) != 1 {
+		return false
+	}
+	switch s.selectors[0].(type) {
+	case nameSelector, indexSelector:
+		return true
+	}
+	return false
+}
+
+func (s *segment) apply(root, v interface{}, nodes []interface{}) []interface{} {
+	if !s.descendant {
+		for _, sel := range s.selectors {
+			nodes = sel.apply(root, v, nodes)
+		}
+		return nodes
+	}
+	walk(v, func(n interface{}) {
+		for _, sel := range s.selectors {
+			nodes = sel.apply(root, n, nodes)
+		}
+	})
+	return nodes
+}
+
+// walk calls fn for v and each of its descendants, in document order.
+func walk(v interface{}, fn func(interface{})) {
+	fn(v)
+	switch v := v.(type) {
+	case []interface{}:
+		for _, e := range v {
+			walk(e, fn)
+		}
+	case map[string]interface{}:
+		for _, k := range sortedKeys(v) {
+			walk(v[k], fn)
+		}
+	}
+}
+
+// children returns the elements of an array or the member values of an object.
+func children(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		values := make([]interface{}, 0, len(v))
+		for _, k := range sortedKeys(v) {
+			values = append(values, v[k])
+		}
+		return values
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of the object m in sorted order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type nameSelector string
+
+func (s nameSelector) apply(_, v interface{}, nodes []interface{}) []interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		if e, ok := m[string(s)]; ok {
+			nodes = append(nodes, e)
+		}
+	}
+	return nodes
+}
+
+type wildcardSelector struct{}
+
+func (wildcardSelector) apply(_, v interface{}, nodes []interface{}) []interface{} {
+	return append(nodes, children(v)...)
+}
+
+type indexSelector int
+
+func (s indexSelector) apply(_, v interface{}, nodes []interface{}) []interface{} {
+	a, ok := v.([]interface{})
+	if !ok {
+		return nodes
+	}
+	i := int(s)
+	if i < 0 {
+		i += len(a)
+	}
+	if i < 0 || i >= len(a) {
+		return nodes
+	}
+	return append(nodes, a[i])
+}
+
+type sliceSelector struct {
+	start, end *int
+	step       int
+}
+
+func (s sliceSelector) apply(_, v interface{}, nodes []interface{}) []interface{} {
+	a, ok := v.([]interface{})
+	if !ok {
+		return nodes
+	}
+	s.each(len(a), func(i int) {
+		nodes = append(nodes, a[i])
+	})
+	return nodes
+}
+
+// each calls fn with each index selected from an array of length n, in order.
+func (s sliceSelector) each(n int, fn func(i int)) {
+	if s.step == 0 {
+		return
+	}
+	normalize := func(i int) int {
+		if i < 0 {
+			return n + i
+		}
+		return i
+	}
+	clamp := func(i, lo, hi int) int {
+		if i < lo {
+			return lo
+		}
+		if i > hi {
+			return hi
+		}
+		return i
+	}
+	if s.step > 0 {
+		start, end := 0, n
+		if s.start != nil {
+			start = normalize(*s.start)
+		}
+		if s.end != nil {
+			end = normalize(*s.end)
+		}
+		for i := clamp(start, 0, n); i < clamp(end, 0, n); i += s.step {
+			fn(i)
+		}
+		return
+	}
+	start, end := n-1, -n-1
+	if s.start != nil {
+		start = normalize(*s.start)
+	}
+	if s.end != nil {
+		end = normalize(*s.end)
+	}
+	for i := clamp(start, -1, n-1); i > clamp(end, -1, n-1); i += s.step {
+		fn(i)
+	}
+}
+
+type filterSelector struct {
+	expr logicalExpr
+}
+
+func (s filterSelector) apply(root, v interface{}, nodes []interface{}) []interface{} {
+	for _, c := range children(v) {
+		if s.expr.eval(root, c) {
+			nodes = append(nodes, c)
+		}
+	}
+	return nodes
+}
+
+// NotFoundError is returned by Path.Get when a singular query selects nothing.
+type NotFoundError struct {
+	// Path is the source of the query.
+	Path string
+
+	// Parent is the source of the query up to the segment which selected
+	// nothing, e.g. "$.user" for "$.user.name". It identifies the deepest node
+	// that exists.
+	Parent string
+
+	// Segment is the source of the segment which selected nothing, e.g.
+	// ".name".
+	Segment string
+
+	// Node is the value selected by Parent.
+	Node interface{}
+
+	// Reason describes why Segment selected nothing from Node.
+	Reason string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s: %s at %s", e.Path, e.Reason, e.Parent)
+}
+
+// notFound returns the error for segment i selecting nothing from v.
+func (p *Path) notFound(i int, v interface{}) error {
+	var parent strings.Builder
+	parent.WriteString("$")
+	for _, seg := range p.segments[:i] {
+		parent.WriteString(seg.src)
+	}
+	seg := p.segments[i]
+
+	var reason string
+	switch sel := seg.selectors[0].(type) {
+	case nameSelector:
+		if _, ok := v.(map[string]interface{}); ok {
+			reason = fmt.Sprintf("key %q not found in object", string(sel))
+		} else {
+			reason = fmt.Sprintf("cannot select key %q from %s", string(sel), TypeName(v))
+		}
+	case indexSelector:
+		if a, ok := v.([]interface{}); ok {
+			reason = fmt.Sprintf("index %d out of range for array of length %d", int(sel), len(a))
+		} else {
+			reason = fmt.Sprintf("cannot select index %d from %s", int(sel), TypeName(v))
+		}
+	}
+	return &NotFoundError{
+		Path:    p.src,
+		Parent:  parent.String(),
+		Segment: seg.src,
+		Node:    v,
+		Reason:  reason,
+	}
+}
+
+// TypeName returns the JSON type of v, e.g. "object" or "string".
+func TypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// SyntaxError is returned by Parse for an invalid query.
+type SyntaxError struct {
+	// Path is the source of the query.
+	Path string
+
+	// Offset is the byte offset in Path at which the error was found.
+	Offset int
+
+	msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid JSONPath %q: %s at offset %d", e.Path, e.msg, e.Offset)
+}
+
+// describe returns a description of the next character, for use in error
+// messages.
+func (p *parser) describe() string {
+	if p.eof() {
+		return "end of path"
+	}
+	r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+	return fmt.Sprintf("character %q", r)
+}
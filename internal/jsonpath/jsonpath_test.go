@@ -0,0 +1,210 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// store is the example document from RFC 9535.
+const store = `{
+	"store": {
+		"book": [
+			{"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+			{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+			{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+			{"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 399}
+	}
+}`
+
+func decode(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestSelect(t *testing.T) {
+	root := decode(t, store)
+	tests := []struct {
+		path string
+		want string
+	}{
+		{`$.store.book[*].author`, `["Nigel Rees", "Evelyn Waugh", "Herman Melville", "J. R. R. Tolkien"]`},
+		{`$..author`, `["Nigel Rees", "Evelyn Waugh", "Herman Melville", "J. R. R. Tolkien"]`},
+		{`$.store.*.color`, `["red"]`},
+		{`$.store..price`, `[399, 8.95, 12.99, 8.99, 22.99]`},
+		{`$..book[2].author`, `["Herman Melville"]`},
+		{`$..book[2].publisher`, `[]`},
+		{`$..book[-1].title`, `["The Lord of the Rings"]`},
+		{`$..book[0,1].title`, `["Sayings of the Century", "Sword of Honour"]`},
+		{`$..book[:2].title`, `["Sayings of the Century", "Sword of Honour"]`},
+		{`$..book[::-2].title`, `["The Lord of the Rings", "Sword of Honour"]`},
+		{`$..book[1:3].price`, `[12.99, 8.99]`},
+		{`$..book[?@.isbn].title`, `["Moby Dick", "The Lord of the Rings"]`},
+		{`$..book[?@.price<10].title`, `["Sayings of the Century", "Moby Dick"]`},
+		{`$..book[?(@.price > 10)].title`, `["Sword of Honour", "The Lord of the Rings"]`},
+		{`$..book[?(@.price > 10 && @.category == 'fiction')].price`, `[12.99, 22.99]`},
+		{`$..book[?@.price < 9 || @.price > 20].price`, `[8.95, 8.99, 22.99]`},
+		{`$..book[?!@.isbn].title`, `["Sayings of the Century", "Sword of Honour"]`},
+		{`$..book[?!(@.price < 10)].title`, `["Sword of Honour", "The Lord of the Rings"]`},
+		{`$..book[?@.price == $.store.book[0].price].title`, `["Sayings of the Century"]`},
+		{`$..book[?length(@.author) > 12].author`, `["Herman Melville", "J. R. R. Tolkien"]`},
+		{`$..book[?match(@.author, 'J.*')].author`, `["J. R. R. Tolkien"]`},
+		{`$..book[?search(@.title, 'of')].title`, `["Sayings of the Century", "Sword of Honour", "The Lord of the Rings"]`},
+		{`$.store[?count(@.*) == 2].color`, `["red"]`},
+		{`$..book[?value(@..isbn) == "0-553-21311-3"].title`, `["Moby Dick"]`},
+		{`$["store"]['bicycle']["color"]`, `["red"]`},
+		{`$.store.bicycle[*]`, `["red", 399]`},
+		{`$..*.color`, `["red"]`},
+	}
+
+	for _, tt := range tests {
+		p, err := Parse(tt.path)
+		if err != nil {
+			t.Errorf("%s: %v", tt.path, err)
+			continue
+		}
+		got := p.Select(root)
+		if want := decode(t, tt.want); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", tt.path, got, want)
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	root := decode(t, store)
+
+	got, err := Lookup(root, `$.store.bicycle.color`)
+	if err != nil || got != "red" {
+		t.Errorf("got %v, %v, want red", got, err)
+	}
+
+	got, err = Lookup(root, `$.store.book[?@.price > 20].title`)
+	if err != nil || !reflect.DeepEqual(got, []interface{}{"The Lord of the Rings"}) {
+		t.Errorf("got %v, %v, want [The Lord of the Rings]", got, err)
+	}
+
+	got, err = Lookup(root, `$.store.book[?@.price > 100]`)
+	if err != nil || !reflect.DeepEqual(got, []interface{}{}) {
+		t.Errorf("got %v, %v, want []", got, err)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	root := decode(t, store)
+	tests := []struct {
+		path    string
+		parent  string
+		segment string
+		msg     string
+	}{
+		{`$.store.bicycle.size`, `$.store.bicycle`, `.size`, `$.store.bicycle.size: key "size" not found in object at $.store.bicycle`},
+		{`$.store.book[10].title`, `$.store.book`, `[10]`, `$.store.book[10].title: index 10 out of range for array of length 4 at $.store.book`},
+		{`$.store.bicycle.color.name`, `$.store.bicycle.color`, `.name`, `$.store.bicycle.color.name: cannot select key "name" from string at $.store.bicycle.color`},
+		{`$.store[0]`, `$.store`, `[0]`, `$.store[0]: cannot select index 0 from object at $.store`},
+	}
+
+	for _, tt := range tests {
+		_, err := Lookup(root, tt.path)
+		nf, ok := err.(*NotFoundError)
+		if !ok {
+			t.Errorf("%s: got %v, want *NotFoundError", tt.path, err)
+			continue
+		}
+		if nf.Parent != tt.parent || nf.Segment != tt.segment || nf.Error() != tt.msg {
+			t.Errorf("%s: got %q %q %q", tt.path, nf.Parent, nf.Segment, nf.Error())
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		path string
+		msg  string
+	}{
+		{`store`, `expected '$', found character 's' at offset 0`},
+		{`$.`, `expected member name, found end of path at offset 2`},
+		{`$.a[`, `expected selector, found end of path at offset 4`},
+		{`$.a[0`, `expected "," or "]", found end of path at offset 5`},
+		{`$.a[01]`, `invalid integer "01" at offset 4`},
+		{`$.a[-0]`, `invalid integer "-0" at offset 4`},
+		{`$.a['b]`, `unterminated string at offset 7`},
+		{`$.a[?@.b == 1 &&]`, `expected literal, query or function, found character ']' at offset 16`},
+		{`$.a[?@.* == 1]`, `non-singular query @.* cannot be compared at offset 5`},
+		{`$.a[?1]`, `literal must be compared at offset 5`},
+		{`$.a[?length(@.b)]`, `result of length() must be compared at offset 5`},
+		{`$.a[?match(@.b, 'x') == true]`, `result of match() cannot be compared at offset 5`},
+		{`$.a[?foo(@.b)]`, `unknown function "foo" at offset 5`},
+		{`$.a[?length(@.*) > 1]`, `invalid argument 1 to length() at offset 12`},
+		{`$.a[?count(@.b, 1) > 1]`, `count() takes 1 argument(s), got 2 at offset 5`},
+		{`$.a b`, `unexpected character ' ' at offset 3`},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.path)
+		if err == nil {
+			t.Errorf("%s: expected error", tt.path)
+			continue
+		}
+		if !strings.HasSuffix(err.Error(), tt.msg) {
+			t.Errorf("%s: got %q, want suffix %q", tt.path, err, tt.msg)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	root := decode(t, store)
+	// steps follows keys from root, recording each node on the way.
+	steps := func(keys ...interface{}) []Step {
+		var result []Step
+		v := root
+		for _, k := range keys {
+			switch k := k.(type) {
+			case string:
+				v = v.(map[string]interface{})[k]
+			case int:
+				v = v.([]interface{})[k]
+			}
+			result = append(result, Step{Key: k, Node: v})
+		}
+		return result
+	}
+	tests := []struct {
+		path  string
+		steps []Step
+		want  bool
+	}{
+		{`$`, nil, true},
+		{`$.store`, steps("store"), true},
+		{`$.store`, steps("store", "bicycle"), false},
+		{`$.store.bicycle.color`, steps("store", "bicycle", "color"), true},
+		{`$.store.*.color`, steps("store", "bicycle", "color"), true},
+		{`$['store']["bicycle"]`, steps("store", "bicycle"), true},
+		{`$.store.book[1]`, steps("store", "book", 1), true},
+		{`$.store.book[-1]`, steps("store", "book", 3), true},
+		{`$.store.book[-1]`, steps("store", "book", 2), false},
+		{`$.store.book[*].title`, steps("store", "book", 2, "title"), true},
+		{`$.store.book[1:3]`, steps("store", "book", 2), true},
+		{`$.store.book[1:3]`, steps("store", "book", 3), false},
+		{`$.store.book[::2]`, steps("store", "book", 2), true},
+		{`$.store.book[::2]`, steps("store", "book", 1), false},
+		{`$..price`, steps("store", "book", 0, "price"), true},
+		{`$..price`, steps("store", "bicycle", "price"), true},
+		{`$..price`, steps("store", "bicycle"), false},
+		{`$..book[?@.isbn].title`, steps("store", "book", 2, "title"), true},
+		{`$..book[?@.isbn].title`, steps("store", "book", 0, "title"), false},
+		{`$.store[0]`, steps("store", "book"), false},
+		{`$.store.book.*`, []Step{{Key: "store"}, {Key: "book"}, {Key: "0"}}, false},
+	}
+	for _, tt := range tests {
+		if got := MustParse(tt.path).Matches(root, tt.steps); got != tt.want {
+			t.Errorf("%s matches %v = %v, want %v", tt.path, tt.steps, got, tt.want)
+		}
+	}
+}
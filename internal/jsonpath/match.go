@@ -0,0 +1,83 @@
+package jsonpath
+
+// Step is a step from a node to one of its children.
+type Step struct {
+	// Key is the name of an object member (a string) or the index of an array
+	// element (an int).
+	Key interface{}
+
+	// Node is the child selected by Key, or nil if it doesn't exist.
+	Node interface{}
+}
+
+// Matches reports whether the query selects the node reached from root by
+// following steps. Each step's parent is the Node of the previous step, or
+// root for the first step. For example, "$.items[*].id" matches the steps
+// ("items", 0, "id").
+func (p *Path) Matches(root interface{}, steps []Step) bool {
+	return p.match(root, root, p.segments, steps)
+}
+
+func (p *Path) match(root, v interface{}, segments []*segment, steps []Step) bool {
+	if len(segments) == 0 {
+		return len(steps) == 0
+	}
+	if len(steps) == 0 {
+		return false
+	}
+	seg, step := segments[0], steps[0]
+	for _, sel := range seg.selectors {
+		if sel.matches(root, v, step) && p.match(root, step.Node, segments[1:], steps[1:]) {
+			return true
+		}
+	}
+	// A descendant segment may also select from any descendant of v.
+	return seg.descendant && p.match(root, step.Node, segments, steps[1:])
+}
+
+func (s nameSelector) matches(_, v interface{}, step Step) bool {
+	_, ok := v.(map[string]interface{})
+	return ok && step.Key == string(s)
+}
+
+func (wildcardSelector) matches(_, v interface{}, step Step) bool {
+	switch v.(type) {
+	case map[string]interface{}:
+		_, ok := step.Key.(string)
+		return ok
+	case []interface{}:
+		_, ok := step.Key.(int)
+		return ok
+	}
+	return false
+}
+
+func (s indexSelector) matches(_, v interface{}, step Step) bool {
+	a, ok := v.([]interface{})
+	key, ok2 := step.Key.(int)
+	if !ok || !ok2 {
+		return false
+	}
+	i := int(s)
+	if i < 0 {
+		i += len(a)
+	}
+	return i == key
+}
+
+func (s sliceSelector) matches(_, v interface{}, step Step) bool {
+	a, ok := v.([]interface{})
+	key, ok2 := step.Key.(int)
+	if !ok || !ok2 {
+		return false
+	}
+	found := false
+	s.each(len(a), func(i int) {
+		found = found || i == key
+	})
+	return found
+}
+
+func (s filterSelector) matches(root, v interface{}, step Step) bool {
+	return wildcardSelector{}.matches(root, v, step) && s.expr.eval(root, step.Node)
+}
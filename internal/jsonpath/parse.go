@@ -0,0 +1,667 @@
+package jsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// maxInt is the largest integer allowed in index and slice selectors, as per
+// I-JSON.
+const maxInt = 1<<53 - 1
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Path: p.src, Offset: p.pos, msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) consume(s string) bool {
+	if strings.HasPrefix(p.src[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(s string) error {
+	if !p.consume(s) {
+		return p.errorf("expected %q, found %s", s, p.describe())
+	}
+	return nil
+}
+
+func (p *parser) skipBlank() {
+	for !p.eof() {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// parseQuery parses a query beginning with the identifier ident ('$' or '@')
+// followed by any number of segments.
+func (p *parser) parseQuery(ident byte) (*Path, error) {
+	start := p.pos
+	if p.peek() != ident {
+		return nil, p.errorf("expected %q, found %s", ident, p.describe())
+	}
+	p.pos++
+	q := &Path{}
+	for {
+		// Blank space may precede a segment, but is otherwise significant (e.g.
+		// in a filter expression), so only consume it if a segment follows.
+		save := p.pos
+		p.skipBlank()
+		if c := p.peek(); c != '.' && c != '[' {
+			p.pos = save
+			break
+		}
+		seg, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		q.segments = append(q.segments, seg)
+	}
+	q.src = p.src[start:p.pos]
+	return q, nil
+}
+
+func (p *parser) parseSegment() (*segment, error) {
+	start := p.pos
+	seg := &segment{}
+	switch {
+	case p.consume(".."):
+		seg.descendant = true
+		switch p.peek() {
+		case '[':
+			sels, err := p.parseBracketed()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = sels
+		case '*':
+			p.pos++
+			seg.selectors = []selector{wildcardSelector{}}
+		default:
+			name, err := p.parseMemberName()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = []selector{nameSelector(name)}
+		}
+	case p.consume("."):
+		if p.consume("*") {
+			seg.selectors = []selector{wildcardSelector{}}
+			break
+		}
+		name, err := p.parseMemberName()
+		if err != nil {
+			return nil, err
+		}
+		seg.selectors = []selector{nameSelector(name)}
+	default:
+		sels, err := p.parseBracketed()
+		if err != nil {
+			return nil, err
+		}
+		seg.selectors = sels
+	}
+	seg.src = p.src[start:p.pos]
+	return seg, nil
+}
+
+// parseMemberName parses the shorthand name in a segment like ".name".
+func (p *parser) parseMemberName() (string, error) {
+	start := p.pos
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		if !isNameFirst(r) && !(p.pos > start && r >= '0' && r <= '9') {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return "", p.errorf("expected member name, found %s", p.describe())
+	}
+	return p.src[start:p.pos], nil
+}
+
+func isNameFirst(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_' ||
+		r >= 0x80 && r != utf8.RuneError
+}
+
+// parseBracketed parses a comma-separated list of selectors in brackets.
+func (p *parser) parseBracketed() ([]selector, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	var sels []selector
+	for {
+		p.skipBlank()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+		p.skipBlank()
+		if p.consume("]") {
+			return sels, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected \",\" or \"]\", found %s", p.describe())
+		}
+	}
+}
+
+func (p *parser) parseSelector() (selector, error) {
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return nameSelector(s), nil
+	case c == '*':
+		p.pos++
+		return wildcardSelector{}, nil
+	case c == '?':
+		p.pos++
+		p.skipBlank()
+		expr, err := p.parseLogicalOr()
+		if err != nil {
+			return nil, err
+		}
+		return filterSelector{expr: expr}, nil
+	case c == '-' || c == ':' || c >= '0' && c <= '9':
+		return p.parseIndexOrSlice()
+	}
+	return nil, p.errorf("expected selector, found %s", p.describe())
+}
+
+func (p *parser) parseIndexOrSlice() (selector, error) {
+	var bounds [3]*int
+	for i := 0; i < 3; i++ {
+		p.skipBlank()
+		if c := p.peek(); c == '-' || c >= '0' && c <= '9' {
+			n, err := p.parseInt()
+			if err != nil {
+				return nil, err
+			}
+			bounds[i] = &n
+			p.skipBlank()
+		}
+		if i == 0 && p.peek() != ':' {
+			if bounds[0] == nil {
+				return nil, p.errorf("expected index, found %s", p.describe())
+			}
+			return indexSelector(*bounds[0]), nil
+		}
+		if i == 2 || !p.consume(":") {
+			break
+		}
+	}
+	s := sliceSelector{start: bounds[0], end: bounds[1], step: 1}
+	if bounds[2] != nil {
+		s.step = *bounds[2]
+	}
+	return s, nil
+}
+
+// parseInt parses an integer in an index or slice selector.
+func (p *parser) parseInt() (int, error) {
+	start := p.pos
+	p.consume("-")
+	digits := p.pos
+	for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	s := p.src[start:p.pos]
+	switch {
+	case p.pos == digits:
+		return 0, p.errorf("expected digit, found %s", p.describe())
+	case s == "-0" || p.src[digits] == '0' && p.pos-digits > 1:
+		p.pos = start
+		return 0, p.errorf("invalid integer %q", s)
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n > maxInt || n < -maxInt {
+		p.pos = start
+		return 0, p.errorf("integer %s out of range", s)
+	}
+	return int(n), nil
+}
+
+// parseString parses a single- or double-quoted string literal.
+func (p *parser) parseString() (string, error) {
+	quote := p.peek()
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.src[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return b.String(), nil
+		case c == '\\':
+			r, err := p.parseEscape(quote)
+			if err != nil {
+				return "", err
+			}
+			b.WriteRune(r)
+		case c < 0x20:
+			return "", p.errorf("invalid control character in string")
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+func (p *parser) parseEscape(quote byte) (rune, error) {
+	p.pos++ // the backslash
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case '/', '\\', quote:
+		return rune(c), nil
+	case 'u':
+		r, err := p.parseHex4()
+		if err != nil {
+			return 0, err
+		}
+		if !utf16.IsSurrogate(r) {
+			return r, nil
+		}
+		if !p.consume(`\u`) {
+			return 0, p.errorf("invalid surrogate pair")
+		}
+		r2, err := p.parseHex4()
+		if err != nil {
+			return 0, err
+		}
+		if r = utf16.DecodeRune(r, r2); r == utf8.RuneError {
+			return 0, p.errorf("invalid surrogate pair")
+		}
+		return r, nil
+	}
+	p.pos--
+	return 0, p.errorf("invalid escape sequence")
+}
+
+func (p *parser) parseHex4() (rune, error) {
+	if p.pos+4 > len(p.src) {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	n, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	p.pos += 4
+	return rune(n), nil
+}
+
+// parseLogicalOr parses a filter expression.
+func (p *parser) parseLogicalOr() (logicalExpr, error) {
+	left, err := p.parseLogicalAnd()
+	if err != nil {
+		return nil, err
+	}
+	or := orExpr{left}
+	for {
+		p.skipBlank()
+		if !p.consume("||") {
+			break
+		}
+		p.skipBlank()
+		right, err := p.parseLogicalAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, right)
+	}
+	if len(or) == 1 {
+		return left, nil
+	}
+	return or, nil
+}
+
+func (p *parser) parseLogicalAnd() (logicalExpr, error) {
+	left, err := p.parseBasic()
+	if err != nil {
+		return nil, err
+	}
+	and := andExpr{left}
+	for {
+		p.skipBlank()
+		if !p.consume("&&") {
+			break
+		}
+		p.skipBlank()
+		right, err := p.parseBasic()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, right)
+	}
+	if len(and) == 1 {
+		return left, nil
+	}
+	return and, nil
+}
+
+func (p *parser) parseBasic() (logicalExpr, error) {
+	if p.peek() == '!' && !strings.HasPrefix(p.src[p.pos:], "!=") {
+		p.pos++
+		p.skipBlank()
+		if p.peek() == '(' {
+			expr, err := p.parseParen()
+			if err != nil {
+				return nil, err
+			}
+			return notExpr{expr}, nil
+		}
+		start := p.pos
+		operand, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		test, err := p.testExpr(operand, start)
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{test}, nil
+	}
+	if p.peek() == '(' {
+		return p.parseParen()
+	}
+
+	start := p.pos
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	save := p.pos
+	p.skipBlank()
+	op := p.parseComparisonOp()
+	if op == "" {
+		p.pos = save
+		return p.testExpr(left, start)
+	}
+	if err := p.checkComparable(left, start); err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	start = p.pos
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkComparable(right, start); err != nil {
+		return nil, err
+	}
+	return comparisonExpr{left: left, op: op, right: right}, nil
+}
+
+func (p *parser) parseParen() (logicalExpr, error) {
+	p.pos++ // the opening paren
+	p.skipBlank()
+	expr, err := p.parseLogicalOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func (p *parser) parseComparisonOp() string {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// testExpr converts operand, which began at offset start, to an existence
+// test. Only queries and functions returning a logical value may be tested.
+func (p *parser) testExpr(operand expr, start int) (logicalExpr, error) {
+	switch x := operand.(type) {
+	case queryExpr:
+		return existsExpr{x}, nil
+	case *funcExpr:
+		if x.fn.result == logicalType {
+			return x, nil
+		}
+		p.pos = start
+		return nil, p.errorf("result of %s() must be compared", x.fn.name)
+	}
+	p.pos = start
+	return nil, p.errorf("literal must be compared")
+}
+
+// checkComparable returns an error unless operand, which began at offset
+// start, can be used in a comparison.
+func (p *parser) checkComparable(operand expr, start int) error {
+	switch x := operand.(type) {
+	case queryExpr:
+		if !x.path.Singular() {
+			p.pos = start
+			return p.errorf("non-singular query %s cannot be compared", x.path.src)
+		}
+	case *funcExpr:
+		if x.fn.result != valueType {
+			p.pos = start
+			return p.errorf("result of %s() cannot be compared", x.fn.name)
+		}
+	}
+	return nil
+}
+
+// parseOperand parses a literal, query or function call in a filter
+// expression.
+func (p *parser) parseOperand() (expr, error) {
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		q, err := p.parseQuery(c)
+		if err != nil {
+			return nil, err
+		}
+		return queryExpr{relative: c == '@', path: q}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return literalExpr{s}, nil
+	case c == '-' || c >= '0' && c <= '9':
+		return p.parseNumber()
+	case c >= 'a' && c <= 'z':
+		for _, lit := range []struct {
+			s string
+			v interface{}
+		}{{"true", true}, {"false", false}, {"null", nil}} {
+			if strings.HasPrefix(p.src[p.pos:], lit.s) && !isFuncNameChar(p.peekAt(len(lit.s))) {
+				p.pos += len(lit.s)
+				return literalExpr{lit.v}, nil
+			}
+		}
+		return p.parseFunc()
+	}
+	return nil, p.errorf("expected literal, query or function, found %s", p.describe())
+}
+
+func (p *parser) peekAt(offset int) byte {
+	if p.pos+offset >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos+offset]
+}
+
+func isFuncNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c == '_' || c >= '0' && c <= '9'
+}
+
+func (p *parser) parseNumber() (expr, error) {
+	start := p.pos
+	p.consume("-")
+	digits := p.pos
+	for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	if p.pos == digits {
+		return nil, p.errorf("expected digit, found %s", p.describe())
+	}
+	if p.src[digits] == '0' && p.pos-digits > 1 {
+		p.pos = start
+		return nil, p.errorf("invalid number with leading zero")
+	}
+	if p.consume(".") {
+		frac := p.pos
+		for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+		if p.pos == frac {
+			return nil, p.errorf("expected digit, found %s", p.describe())
+		}
+	}
+	if c := p.peek(); c == 'e' || c == 'E' {
+		p.pos++
+		if c := p.peek(); c == '+' || c == '-' {
+			p.pos++
+		}
+		exp := p.pos
+		for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+		if p.pos == exp {
+			return nil, p.errorf("expected digit, found %s", p.describe())
+		}
+	}
+	n, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number")
+	}
+	return literalExpr{n}, nil
+}
+
+func (p *parser) parseFunc() (expr, error) {
+	start := p.pos
+	for !p.eof() && isFuncNameChar(p.peek()) {
+		p.pos++
+	}
+	name := p.src[start:p.pos]
+	fn, ok := functions[name]
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("unknown function %q", name)
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	call := &funcExpr{fn: fn}
+	for {
+		p.skipBlank()
+		if len(call.args) == 0 && p.consume(")") {
+			break
+		}
+		argStart := p.pos
+		arg, err := p.parseFuncArg()
+		if err != nil {
+			return nil, err
+		}
+		if i := len(call.args); i < len(fn.params) {
+			if err := p.checkArg(fn, i, arg, argStart); err != nil {
+				return nil, err
+			}
+		}
+		call.args = append(call.args, arg)
+		p.skipBlank()
+		if p.consume(")") {
+			break
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+	if len(call.args) != len(fn.params) {
+		p.pos = start
+		return nil, p.errorf("%s() takes %d argument(s), got %d", name, len(fn.params), len(call.args))
+	}
+	return call, nil
+}
+
+// parseFuncArg parses a function argument, which may be a logical expression
+// as well as an operand.
+func (p *parser) parseFuncArg() (expr, error) {
+	start := p.pos
+	operand, err := p.parseOperand()
+	if err == nil {
+		save := p.pos
+		p.skipBlank()
+		if c := p.peek(); c == ',' || c == ')' {
+			p.pos = save
+			return operand, nil
+		}
+	}
+	p.pos = start
+	return p.parseLogicalOr()
+}
+
+// checkArg returns an error unless arg, which began at offset start, is valid
+// for the i'th parameter of fn.
+func (p *parser) checkArg(fn *function, i int, arg expr, start int) error {
+	ok := false
+	switch want := fn.params[i]; x := arg.(type) {
+	case literalExpr:
+		ok = want == valueType
+	case queryExpr:
+		ok = want == nodesType || want == logicalType || want == valueType && x.path.Singular()
+	case *funcExpr:
+		ok = want == x.fn.result || want == logicalType && x.fn.result == nodesType
+	case logicalExpr:
+		ok = want == logicalType
+	}
+	if !ok {
+		p.pos = start
+		return p.errorf("invalid argument %d to %s()", i+1, fn.name)
+	}
+	return nil
+}
@@ -0,0 +1,665 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/deliveroo/assert-go/internal/jsonpath"
+	"github.com/google/go-cmp/cmp"
+)
+
+// IgnoreJSON configures JSONEqual, JSONContains and JSONPath to ignore the
+// specified locations in the JSON values being compared. Each location is
+// either a JSON Pointer (e.g. "/user/id") or a JSONPath query (e.g.
+// "$.items[*].id"), which may omit the leading "$." as in JSONPath. It panics
+// if a query is invalid.
+func IgnoreJSON(locations ...string) cmp.Option {
+	l := parseJSONLocations("IgnoreJSON", locations)
+	return ignoreJSON{Option: l.ignore(nil), locations: l}
+}
+
+// ignoreJSON is the option returned by IgnoreJSON. It wraps a filter ignoring
+// its locations, to which it is replaced by unwrapOptions, but the JSON
+// assertions keep it so that they can ignore its locations when comparing the
+// elements of unordered arrays on their own.
+type ignoreJSON struct {
+	cmp.Option
+	locations *jsonLocations
+}
+
+// unorderedArrays is the option returned by UnorderedArrays. The JSON
+// assertions remove it from their options, and reorder the arrays themselves
+// before comparing them.
+type unorderedArrays struct {
+	cmp.Option
+
+	// locations holds the locations of the arrays affected, or nil if all
+	// arrays are.
+	locations *jsonLocations
+}
+
+// UnorderedArrays configures JSON assertions to treat arrays as sets, so that
+// their elements may be in any order. If locations are given (as in
+// IgnoreJSON), only the arrays at those locations are affected. For example:
+//
+//     assert.JSONEqual(t, resp, want, assert.UnorderedArrays("$.tags"))
+//
+// Elements are paired by comparing them with the other options applied, so
+// that options such as IgnoreJSON and FloatTolerance apply within them, and
+// differences are reported at the locations of the elements in got. Elements
+// which are identical apart from ignored locations are paired without being
+// compared, so large arrays are only slow when other options must be applied.
+// The option has no meaning outside of the JSON assertions, and the others
+// ignore it.
+func UnorderedArrays(locations ...string) cmp.Option {
+	var l *jsonLocations
+	if len(locations) > 0 {
+		l = parseJSONLocations("UnorderedArrays", locations)
+	}
+	return unorderedArrays{locations: l}
+}
+
+// NullEqualsMissing configures JSON assertions to treat an object member with
+// a null value as equivalent to the member being absent, so that
+// {"id": 1, "name": null} is equal to {"id": 1}.
+func NullEqualsMissing() cmp.Option {
+	return cmp.Transformer("NullEqualsMissing", func(m map[string]interface{}) map[string]interface{} {
+		result := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			if v != nil {
+				result[k] = v
+			}
+		}
+		return result
+	})
+}
+
+// JSONContains asserts that want is a subset of got when both are represented
+// as JSON. Keys of objects in got which are absent from want are ignored, so
+// that only the fields of interest need to be specified, for example:
+//
+//     assert.JSONContains(t, resp, `{"user": {"name": "Alice"}}`)
+//
+// Arrays must have the same length, and their elements are matched in order
+// unless the UnorderedArrays option is given, in which case each element of
+// want must match a different element of got, wherever it is, and any other
+// elements of got are ignored. On failure, the diff is limited to the keys
+// present in want.
+func JSONContains(t testingT, got, want interface{}, opts ...cmp.Option) bool {
+	t.Helper()
+	t, opts = annotate(t, opts)
+	got, ok := decodeJSON(t, 1, got)
+	if !ok {
+		return false
+	}
+	want, ok = decodeJSON(t, 2, want)
+	if !ok {
+		return false
+	}
+	p := newProjector(got, true, collectOptions(t, opts))
+	got, want = p.project(got, want, nil)
+	return reportJSONDiff(t, getArg(1), got, want, unwrapOptions(p.opts), p.indexes)
+}
+
+// projector prepares two JSON values for comparison: it reorders the arrays
+// of want whose elements may be in any order to line up with the elements of
+// got, and for JSONContains, reduces got to the shape of want.
+type projector struct {
+	root interface{}
+
+	// partial reports whether objects in got are reduced to the keys present
+	// in want, and unordered arrays in got to the elements matching those of
+	// want, as for JSONContains.
+	partial bool
+
+	// unordered holds the locations of arrays whose elements may be in any
+	// order. A nil entry matches all arrays.
+	unordered []*jsonLocations
+
+	// ignored holds the locations given to IgnoreJSON.
+	ignored []*jsonLocations
+
+	// opts holds the options to compare with, other than UnorderedArrays.
+	// Those which cmp doesn't accept must be unwrapped (see unwrapOptions)
+	// before use.
+	opts []cmp.Option
+
+	// indexes maps the arrays which have been reordered, by address, to the
+	// original indexes of their elements, so that differences can be reported
+	// at their original locations. It is nil while projecting elements only to
+	// compare them.
+	indexes map[uintptr][]int
+}
+
+// newProjector returns a projector for comparing got, the root of the values
+// being compared, with the options opts, which include any UnorderedArrays
+// options.
+func newProjector(got interface{}, partial bool, opts []cmp.Option) *projector {
+	p := &projector{root: got, partial: partial, indexes: map[uintptr][]int{}}
+	p.unordered, p.opts = splitUnorderedArrays(opts)
+	p.ignored = findIgnoreJSON(p.opts)
+	return p
+}
+
+// project returns got, found at steps from the root, and want, prepared for
+// comparison: the elements of unordered arrays in want are reordered so that
+// elements matching those of got are at the same index, and if the projection
+// is partial, objects in got are reduced to the keys present in want and
+// unordered arrays to the elements matching those of want. Comparing the
+// results then reports only the differences which matter.
+func (p *projector) project(got, want interface{}, steps []jsonpath.Step) (interface{}, interface{}) {
+	switch want := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return got, want
+		}
+		gotResult := make(map[string]interface{}, len(want))
+		if !p.partial {
+			for k, v := range g {
+				gotResult[k] = v
+			}
+		}
+		wantResult := make(map[string]interface{}, len(want))
+		for k, w := range want {
+			wantResult[k] = w
+			if v, ok := g[k]; ok {
+				gotResult[k], wantResult[k] = p.project(v, w, appendStep(steps, k, v))
+			}
+		}
+		return gotResult, wantResult
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok {
+			return got, want
+		}
+		var gotIndexes, wantIndexes []int
+		if p.isUnordered(steps) {
+			g, gotIndexes, want, wantIndexes = p.align(g, want, p.pair(g, want, steps))
+		}
+		gotResult := make([]interface{}, len(g))
+		copy(gotResult, g)
+		wantResult := make([]interface{}, len(want))
+		copy(wantResult, want)
+		for i := 0; i < len(g) && i < len(want); i++ {
+			j := i
+			if gotIndexes != nil {
+				j = gotIndexes[i]
+			}
+			gotResult[i], wantResult[i] = p.project(g[i], want[i], appendStep(steps, j, g[i]))
+		}
+		p.recordIndexes(gotResult, gotIndexes)
+		p.recordIndexes(wantResult, wantIndexes)
+		return gotResult, wantResult
+	}
+	return got, want
+}
+
+// align arranges the elements of got and want so that those paired by pair
+// (see projector.pair) are at the same index, returning them along with the
+// original index of each element, or nil if the elements of a slice haven't
+// moved.
+//
+// For JSONEqual, want is rearranged to follow got, with the elements not
+// paired filling the remaining indexes in their original order. For
+// JSONContains, got is reduced to the elements paired with those of want, in
+// the order of want. Elements of want not paired are matched against the
+// remaining elements of got in order, so that their differences are reported,
+// and if there are none left, moved to the end.
+func (p *projector) align(got, want []interface{}, pair []int) (g []interface{}, gotIndexes []int, w []interface{}, wantIndexes []int) {
+	if !p.partial {
+		w = make([]interface{}, len(want))
+		wantIndexes = make([]int, len(want))
+		filled := make([]bool, len(want))
+		for i, j := range pair {
+			if j >= 0 {
+				w[j], wantIndexes[j], filled[j] = want[i], i, true
+			}
+		}
+		next := 0
+		for i, j := range pair {
+			if j >= 0 {
+				continue
+			}
+			for filled[next] {
+				next++
+			}
+			w[next], wantIndexes[next] = want[i], i
+			next++
+		}
+		return got, nil, w, wantIndexes
+	}
+
+	used := make([]bool, len(got))
+	for _, j := range pair {
+		if j >= 0 {
+			used[j] = true
+		}
+	}
+	next := 0
+	var missing []int
+	for i, j := range pair {
+		if j < 0 {
+			for next < len(got) && used[next] {
+				next++
+			}
+			if next == len(got) {
+				missing = append(missing, i)
+				continue
+			}
+			j, used[next] = next, true
+		}
+		g = append(g, got[j])
+		gotIndexes = append(gotIndexes, j)
+		w = append(w, want[i])
+		wantIndexes = append(wantIndexes, i)
+	}
+	for _, i := range missing {
+		w = append(w, want[i])
+		wantIndexes = append(wantIndexes, i)
+	}
+	return g, gotIndexes, w, wantIndexes
+}
+
+// recordIndexes records the original indexes of the elements of the array a,
+// if they have moved.
+func (p *projector) recordIndexes(a []interface{}, indexes []int) {
+	if p.indexes == nil || len(a) == 0 || indexes == nil {
+		return
+	}
+	for i, orig := range indexes {
+		if i != orig {
+			p.indexes[reflect.ValueOf(a).Pointer()] = indexes
+			return
+		}
+	}
+}
+
+// pair pairs the elements of want with the elements of got they match,
+// returning the index of the element of got paired with each element of want,
+// or -1. Elements are paired by maximum bipartite matching, so that an element
+// of want matching several elements of got isn't paired with the first of them
+// at the expense of the others.
+//
+// So that not every element of want needs to be compared with every element
+// of got, elements which are identical, apart from the locations given to
+// IgnoreJSON, are paired first. The elements of got another element of want
+// matches are only found if it needs to be paired, or to give up its element.
+func (p *projector) pair(got, want []interface{}, steps []jsonpath.Step) []int {
+	// For JSONEqual, want is rearranged to follow got, so its elements can
+	// only be paired with elements of got at the indexes it has.
+	n := len(got)
+	if !p.partial && len(want) < n {
+		n = len(want)
+	}
+
+	// owner holds the index of the element of want paired with each element
+	// of got, or -1.
+	owner := make([]int, n)
+	for j := range owner {
+		owner[j] = -1
+	}
+	pair := make([]int, len(want))
+	for i := range pair {
+		pair[i] = -1
+	}
+
+	byKey := make(map[string][]int)
+	for j := 0; j < n; j++ {
+		k := p.key(got[j], appendStep(steps, j, got[j]))
+		byKey[k] = append(byKey[k], j)
+	}
+	for i, w := range want {
+		k := p.key(w, appendStep(steps, i, w))
+		if js := byKey[k]; len(js) > 0 {
+			pair[i], owner[js[0]] = js[0], i
+			byKey[k] = js[1:]
+		}
+	}
+
+	// opts holds the options for comparing each element of got, and matched
+	// whether each element of want matches each element of got, once known.
+	opts := make([][]cmp.Option, n)
+	matched := make([]map[int]bool, len(want))
+	matches := func(i, j int) bool {
+		if ok, known := matched[i][j]; known {
+			return ok
+		}
+		elem := appendStep(steps, j, got[j])
+		if opts[j] == nil {
+			opts[j] = p.elementOptions(elem)
+		}
+		ok := p.matches(got[j], want[i], elem, opts[j])
+		if matched[i] == nil {
+			matched[i] = make(map[int]bool)
+		}
+		matched[i][j] = ok
+		return ok
+	}
+
+	// Each element of want not yet paired takes the first element of got
+	// still free that it matches, which is usually enough.
+	var unpaired []int
+	for i := range want {
+		if pair[i] >= 0 {
+			continue
+		}
+		for j := 0; j < n; j++ {
+			if owner[j] < 0 && matches(i, j) {
+				pair[i], owner[j] = j, i
+				break
+			}
+		}
+		if pair[i] < 0 {
+			unpaired = append(unpaired, i)
+		}
+	}
+
+	// Those left look for an augmenting path through the pairs made so far.
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for j := 0; j < n; j++ {
+			if seen[j] || !matches(i, j) {
+				continue
+			}
+			seen[j] = true
+			if owner[j] < 0 || augment(owner[j], seen) {
+				owner[j], pair[i] = i, j
+				return true
+			}
+		}
+		return false
+	}
+	for _, i := range unpaired {
+		augment(i, make([]bool, n))
+	}
+	return pair
+}
+
+// key returns a representation of the JSON value v, found at steps, which is
+// the same for values which are identical apart from the locations given to
+// IgnoreJSON.
+func (p *projector) key(v interface{}, steps []jsonpath.Step) string {
+	var b strings.Builder
+	p.writeKey(&b, v, steps)
+	return b.String()
+}
+
+func (p *projector) writeKey(b *strings.Builder, v interface{}, steps []jsonpath.Step) {
+	if p.isIgnored(steps) {
+		return
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteByte('{')
+		for _, k := range keys {
+			b.WriteString(strconv.Quote(k) + ":")
+			p.writeKey(b, v[k], appendStep(steps, k, v[k]))
+			b.WriteByte(',')
+		}
+		b.WriteByte('}')
+	case []interface{}:
+		b.WriteByte('[')
+		for i, e := range v {
+			p.writeKey(b, e, appendStep(steps, i, e))
+			b.WriteByte(',')
+		}
+		b.WriteByte(']')
+	default:
+		fmt.Fprintf(b, "%T:%#v", v, v)
+	}
+}
+
+func (p *projector) isIgnored(steps []jsonpath.Step) bool {
+	for _, l := range p.ignored {
+		if l.matches(p.root, steps) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether the element of got found at steps matches the
+// element of want, compared with opts (see elementOptions).
+func (p *projector) matches(got, want interface{}, steps []jsonpath.Step, opts []cmp.Option) bool {
+	trial := *p
+	trial.indexes = nil
+	got, want = trial.project(got, want, steps)
+	return cmp.Equal(got, want, opts...)
+}
+
+// elementOptions returns the options for comparing the element of got found at
+// steps on its own, with the locations given to IgnoreJSON taken relative to
+// the root rather than to the element.
+func (p *projector) elementOptions(steps []jsonpath.Step) []cmp.Option {
+	return unwrapOptions(rebaseOptions(p.opts, &jsonBase{root: p.root, steps: steps}))
+}
+
+// rebaseOptions returns opts with the IgnoreJSON options replaced by filters
+// ignoring their locations in values found at base.
+func rebaseOptions(opts []cmp.Option, base *jsonBase) []cmp.Option {
+	result := make([]cmp.Option, 0, len(opts))
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case cmp.Options:
+			result = append(result, cmp.Options(rebaseOptions(opt, base)))
+		case ignoreJSON:
+			result = append(result, opt.locations.ignore(base))
+		default:
+			result = append(result, opt)
+		}
+	}
+	return result
+}
+
+// findIgnoreJSON returns the locations of the IgnoreJSON options in opts.
+func findIgnoreJSON(opts []cmp.Option) []*jsonLocations {
+	var result []*jsonLocations
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case cmp.Options:
+			result = append(result, findIgnoreJSON(opt)...)
+		case ignoreJSON:
+			result = append(result, opt.locations)
+		}
+	}
+	return result
+}
+
+// jsonBase is the location of the values being compared within the root of a
+// JSON value, when they are elements of it compared on their own.
+type jsonBase struct {
+	root  interface{}
+	steps []jsonpath.Step
+}
+
+// resolve converts the root and steps of a location within a value found at
+// the base into the root and steps of the same location within the base's
+// root.
+func (b *jsonBase) resolve(root interface{}, steps []jsonpath.Step) (interface{}, []jsonpath.Step) {
+	if b == nil || len(b.steps) == 0 {
+		return root, steps
+	}
+	n := len(b.steps)
+	result := make([]jsonpath.Step, 0, n+len(steps))
+	result = append(result, b.steps[:n-1]...)
+	result = append(result, jsonpath.Step{Key: b.steps[n-1].Key, Node: root})
+	return b.root, append(result, steps...)
+}
+
+func (p *projector) isUnordered(steps []jsonpath.Step) bool {
+	for _, l := range p.unordered {
+		if l == nil || l.matches(p.root, steps) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitUnorderedArrays separates the UnorderedArrays options from opts,
+// returning the locations they apply to and the remaining options.
+func splitUnorderedArrays(opts []cmp.Option) (locations []*jsonLocations, rest []cmp.Option) {
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case cmp.Options:
+			l, r := splitUnorderedArrays(opt)
+			locations = append(locations, l...)
+			rest = append(rest, cmp.Options(r))
+		case unorderedArrays:
+			locations = append(locations, opt.locations)
+		default:
+			rest = append(rest, opt)
+		}
+	}
+	return locations, rest
+}
+
+// appendStep returns a copy of steps with a step to node, the child of the last
+// step identified by key.
+func appendStep(steps []jsonpath.Step, key, node interface{}) []jsonpath.Step {
+	return append(steps[:len(steps):len(steps)], jsonpath.Step{Key: key, Node: node})
+}
+
+// jsonLocations is a set of locations within a JSON value, given as JSONPath
+// queries or JSON Pointers.
+type jsonLocations struct {
+	paths    []*jsonpath.Path
+	pointers [][]string
+}
+
+// parseJSONLocations parses the locations given to the option fn, panicking
+// if any are invalid.
+func parseJSONLocations(fn string, locations []string) *jsonLocations {
+	l := &jsonLocations{}
+	for _, loc := range locations {
+		if loc == "" || strings.HasPrefix(loc, "/") {
+			l.pointers = append(l.pointers, parsePointer(loc))
+			continue
+		}
+		p, err := jsonpath.Parse(jsonPathQuery(loc))
+		if err != nil {
+			panic(fmt.Sprintf("assert: %s: %v", fn, err))
+		}
+		l.paths = append(l.paths, p)
+	}
+	return l
+}
+
+// pointerUnescaper decodes the escape sequences in a JSON Pointer token.
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// parsePointer splits a JSON Pointer into its reference tokens, as specified
+// by RFC 6901.
+func parsePointer(ptr string) []string {
+	if ptr == "" {
+		return nil
+	}
+	tokens := strings.Split(ptr[1:], "/")
+	for i, tok := range tokens {
+		tokens[i] = pointerUnescaper.Replace(tok)
+	}
+	return tokens
+}
+
+// matches reports whether the node reached from root by following steps is
+// one of the locations.
+func (l *jsonLocations) matches(root interface{}, steps []jsonpath.Step) bool {
+	for _, p := range l.paths {
+		if p.Matches(root, steps) {
+			return true
+		}
+	}
+	for _, tokens := range l.pointers {
+		if pointerMatches(tokens, steps) {
+			return true
+		}
+	}
+	return false
+}
+
+func pointerMatches(tokens []string, steps []jsonpath.Step) bool {
+	if len(tokens) != len(steps) {
+		return false
+	}
+	for i, step := range steps {
+		if fmt.Sprint(step.Key) != tokens[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ignore returns an option ignoring the locations in values found at base, or
+// in the values being compared if base is nil.
+func (l *jsonLocations) ignore(base *jsonBase) cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		return l.filter(base, p)
+	}, cmp.Ignore())
+}
+
+// filter reports whether the cmp path is one of the locations, in either of
+// the values being compared, which are found at base (see ignore).
+func (l *jsonLocations) filter(base *jsonBase, p cmp.Path) bool {
+	for side := 0; side < 2; side++ {
+		if root, steps, ok := jsonSteps(p, side); ok && l.matches(base.resolve(root, steps)) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonSteps converts a cmp path through decoded JSON values into the root and
+// the steps from it, for the first (side 0) or second (side 1) value being
+// compared. It reports false if the path isn't through JSON values or doesn't
+// exist in that value.
+func jsonSteps(p cmp.Path, side int) (root interface{}, steps []jsonpath.Step, ok bool) {
+	value := func(ps cmp.PathStep) interface{} {
+		vx, vy := ps.Values()
+		v := vx
+		if side == 1 {
+			v = vy
+		}
+		if !v.IsValid() || !v.CanInterface() {
+			return nil
+		}
+		return v.Interface()
+	}
+	root = value(p.Index(0))
+	for _, ps := range p[1:] {
+		switch s := ps.(type) {
+		case cmp.MapIndex:
+			if s.Key().Kind() != reflect.String {
+				return nil, nil, false
+			}
+			steps = append(steps, jsonpath.Step{Key: s.Key().String(), Node: value(s)})
+		case cmp.SliceIndex:
+			ix, iy := s.SplitKeys()
+			i := ix
+			if side == 1 {
+				i = iy
+			}
+			if i < 0 {
+				return nil, nil, false
+			}
+			steps = append(steps, jsonpath.Step{Key: i, Node: value(s)})
+		case cmp.Transform:
+			// A transformation (e.g. by NullEqualsMissing) replaces the node.
+			if len(steps) == 0 {
+				root = value(s)
+			} else {
+				steps[len(steps)-1].Node = value(s)
+			}
+		case cmp.StructField:
+			return nil, nil, false
+		}
+	}
+	return root, steps, true
+}
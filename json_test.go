@@ -0,0 +1,352 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestJSONContains(t *testing.T) {
+	resp := `{
+		"id": "ord_123",
+		"created_at": "2020-01-01T00:00:00Z",
+		"user": {"id": 1, "name": "Alice"},
+		"items": [{"sku": "a", "qty": 1}, {"sku": "b", "qty": 2}]
+	}`
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONContains(mt, resp, `{"user": {"name": "Alice"}}`)
+	}, ``)
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONContains(mt, resp, `{"items": [{"sku": "a"}, {"sku": "b"}]}`)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONContains(mt, resp, `{"user": {"name": "Bob"}}`)
+		},
+		`resp (-got +want):`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONContains(mt, resp, `{"status": "paid"}`)
+		},
+		`resp (-got +want):`)
+
+	t.Run("diff is limited to keys in want", func(t *testing.T) {
+		mt := &mockTestingT{}
+		JSONContains(mt, resp, `{"user": {"name": "Bob"}}`)
+		if strings.Contains(mt.err, "created_at") || strings.Contains(mt.err, "ord_123") {
+			t.Errorf("diff includes keys absent from want:\n%s", mt.err)
+		}
+	})
+
+	t.Run("arrays are ordered by default", func(t *testing.T) {
+		assert(t,
+			func(mt *mockTestingT) bool {
+				return JSONContains(mt, resp, `{"items": [{"sku": "b"}, {"sku": "a"}]}`)
+			},
+			`resp (-got +want):`)
+
+		assert(t,
+			func(mt *mockTestingT) bool {
+				return JSONContains(mt, resp, `{"items": [{"sku": "a"}]}`)
+			},
+			`resp (-got +want):`)
+	})
+
+	t.Run("unordered arrays", func(t *testing.T) {
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONContains(mt, resp, `{"items": [{"sku": "b", "qty": 2}, {"sku": "a"}]}`, UnorderedArrays())
+		}, ``)
+
+		assert(t,
+			func(mt *mockTestingT) bool {
+				return JSONContains(mt, resp, `{"items": [{"sku": "b"}, {"sku": "c"}]}`, UnorderedArrays())
+			},
+			`resp (-got +want):`)
+
+		// Elements of want may match elements of got at any index, and the
+		// other elements of got are ignored.
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONContains(mt, resp, `{"items": [{"sku": "b"}]}`, UnorderedArrays())
+		}, ``)
+
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONContains(mt, `["a", "b", "c", "d"]`, `["d", "b"]`, UnorderedArrays())
+		}, ``)
+
+		assert(t,
+			func(mt *mockTestingT) bool {
+				return JSONContains(mt, `["a", "b", "c", "d"]`, `["d", "d"]`, UnorderedArrays())
+			},
+			dedent(`
+			`+"`"+`["a", "b", "c", "d"]`+"`"+` (-got +want):
+			/0 (want /1):
+			  - "a"
+			  + "d"`))
+
+		// The first element of want matches both elements of got, but only
+		// the first element of got matches the second element of want.
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONContains(mt, `[{"a": 1, "b": 2}, {"a": 1}]`, `[{"a": 1}, {"a": 1, "b": 2}]`, UnorderedArrays())
+		}, ``)
+	})
+
+	t.Run("structs", func(t *testing.T) {
+		type user struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+		u := user{ID: 1, Name: "Alice"}
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONContains(mt, u, map[string]interface{}{"name": "Alice"})
+		}, ``)
+	})
+}
+
+func TestPair(t *testing.T) {
+	got := []interface{}{"a", "b", "c", "d"}
+	want := []interface{}{"c", "x", "a"}
+
+	p := newProjector(got, false, nil)
+	pair := p.pair(got, want, nil)
+	assertEQ(t, pair, []int{2, -1, 0})
+	_, gotIndexes, aligned, wantIndexes := p.align(got, want, pair)
+	assertEQ(t, gotIndexes, []int(nil))
+	assertEQ(t, aligned, []interface{}{"a", "x", "c"})
+	assertEQ(t, wantIndexes, []int{2, 1, 0})
+
+	// For JSONContains, got is reduced to the elements paired with want.
+	p = newProjector(got, true, nil)
+	want = []interface{}{"d", "x", "a"}
+	pair = p.pair(got, want, nil)
+	assertEQ(t, pair, []int{3, -1, 0})
+	reduced, gotIndexes, _, _ := p.align(got, want, pair)
+	assertEQ(t, reduced, []interface{}{"d", "b", "a"})
+	assertEQ(t, gotIndexes, []int{3, 1, 0})
+
+	pair = p.pair([]interface{}{"a"}, []interface{}{"x", "a"}, nil)
+	assertEQ(t, pair, []int{-1, 0})
+	reduced, _, aligned, wantIndexes = p.align([]interface{}{"a"}, []interface{}{"x", "a"}, pair)
+	assertEQ(t, reduced, []interface{}{"a"})
+	assertEQ(t, aligned, []interface{}{"a", "x"})
+	assertEQ(t, wantIndexes, []int{1, 0})
+}
+
+func TestIgnoreJSON(t *testing.T) {
+	got := `{"id": "ord_1", "user": {"id": 7, "name": "Alice"}, "items": [{"id": 1, "sku": "a"}, {"id": 2, "sku": "b"}]}`
+	want := `{"id": "ord_2", "user": {"id": 8, "name": "Alice"}, "items": [{"id": 3, "sku": "a"}, {"id": 4, "sku": "b"}]}`
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONEqual(mt, got, want, IgnoreJSON("/id", "$.user.id", "items[*].id"))
+	}, ``)
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONEqual(mt, got, want, IgnoreJSON("..id"))
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONEqual(mt, got, want, IgnoreJSON("/id", "/user/id"))
+		},
+		`got (-got +want):`)
+
+	t.Run("missing keys", func(t *testing.T) {
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONEqual(mt, `{"a": 1, "updated_at": "now"}`, `{"a": 1}`, IgnoreJSON("/updated_at"))
+		}, ``)
+	})
+
+	t.Run("escaped pointer", func(t *testing.T) {
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONEqual(mt, `{"a/b": 1, "c~d": 2}`, `{}`, IgnoreJSON("/a~1b", "/c~0d"))
+		}, ``)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONEqual(mt, got, `{"id": "ord_1", "user": {"id": 7, "name": "Alice"}, "items": [{"id": 1, "sku": "a"}, {"id": 5, "sku": "b"}]}`,
+				IgnoreJSON("$.items[?@.sku == 'b'].id"))
+		}, ``)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		defer func() {
+			assertEQ(t, recover(), `assert: IgnoreJSON: invalid JSONPath "$.items[": expected selector, found end of path at offset 8`)
+		}()
+		IgnoreJSON("items[")
+	})
+}
+
+func TestUnorderedArrays(t *testing.T) {
+	got := `{"tags": ["a", "b", "c"], "steps": [1, 2]}`
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONEqual(mt, got, `{"tags": ["c", "a", "b"], "steps": [2, 1]}`, UnorderedArrays())
+	}, ``)
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONEqual(mt, got, `{"tags": ["c", "a", "b"], "steps": [1, 2]}`, UnorderedArrays("$.tags"))
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONEqual(mt, got, `{"tags": ["c", "a", "b"], "steps": [2, 1]}`, UnorderedArrays("/tags"))
+		},
+		`got (-got +want):`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONEqual(mt, got, `{"tags": ["c", "a"], "steps": [1, 2]}`, UnorderedArrays())
+		},
+		`got (-got +want):`)
+
+	t.Run("nested objects", func(t *testing.T) {
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONEqual(mt,
+				`[{"id": 1, "roles": ["x", "y"]}, {"id": 2}]`,
+				`[{"id": 2}, {"id": 1, "roles": ["y", "x"]}]`,
+				UnorderedArrays())
+		}, ``)
+	})
+
+	t.Run("with other options", func(t *testing.T) {
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONEqual(mt,
+				`{"items": [{"id": 2, "sku": "a"}, {"id": 1, "sku": "b"}]}`,
+				`{"items": [{"id": 9, "sku": "b"}, {"id": 8, "sku": "a"}]}`,
+				UnorderedArrays(), IgnoreJSON("$.items[*].id"))
+		}, ``)
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONEqual(mt, `[{"total": 10.004}, {"total": 5}]`, `[{"total": 5}, {"total": 10}]`,
+				UnorderedArrays(), FloatTolerance(0.01))
+		}, ``)
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONEqual(mt,
+				`{"items": [{"id": 2, "sku": "a"}, {"id": 1, "sku": "b"}]}`,
+				`{"items": [{"id": 9, "sku": "b"}, {"id": 2, "sku": "a"}]}`,
+				UnorderedArrays(), IgnoreJSON("$.items[?@.sku == 'b'].id"))
+		}, ``)
+
+		// Locations are relative to the root, not to the elements.
+		assert(t,
+			func(mt *mockTestingT) bool {
+				return JSONEqual(mt,
+					`{"id": 1, "items": [{"id": 1}, {"id": 2}]}`,
+					`{"id": 1, "items": [{"id": 3}, {"id": 1}]}`,
+					UnorderedArrays(), IgnoreJSON("/id"))
+			},
+			dedent(`
+			`+"`"+`{"id": 1, "items": [{"id": 1}, {"id": 2}]}`+"`"+` (-got +want):
+			/items/1/id (want /items/0/id):
+			  - 2
+			  + 3`))
+	})
+
+	t.Run("original locations", func(t *testing.T) {
+		assert(t,
+			func(mt *mockTestingT) bool {
+				return JSONEqual(mt, got, `{"tags": ["x", "c", "a"], "steps": [2, 1]}`, UnorderedArrays())
+			},
+			dedent(`
+			got (-got +want):
+			/tags/1 (want /tags/0):
+			  - "b"
+			  + "x"`))
+		assert(t,
+			func(mt *mockTestingT) bool {
+				return JSONEqual(mt,
+					`{"items": [{"sku": "a", "qty": 1}, {"sku": "b", "qty": 2}]}`,
+					`{"items": [{"sku": "b", "qty": 3}, {"sku": "a", "qty": 1}]}`,
+					UnorderedArrays())
+			},
+			dedent(`
+			`+"`"+`{"items": [{"sku": "a", "qty": 1}, {"sku": "b", "qty": 2}]}`+"`"+` (-got +want):
+			/items/1/qty (want /items/0/qty):
+			  - 2
+			  + 3`))
+	})
+
+	t.Run("JSONContains at location", func(t *testing.T) {
+		resp := `{"items": [{"sku": "a", "qty": 1}, {"sku": "b", "qty": 2}], "tags": ["x", "y"]}`
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONContains(mt, resp, `{"items": [{"sku": "b"}, {"sku": "a"}]}`, UnorderedArrays("items"))
+		}, ``)
+		assert(t,
+			func(mt *mockTestingT) bool {
+				return JSONContains(mt, resp, `{"tags": ["y", "x"]}`, UnorderedArrays("items"))
+			},
+			`resp (-got +want):`)
+	})
+}
+
+func TestFloatTolerance(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONEqual(mt, `{"total": 10.004}`, `{"total": 10}`, FloatTolerance(0.01))
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONEqual(mt, `{"total": 10.02}`, `{"total": 10}`, FloatTolerance(0.01))
+		},
+		"`{\"total\": 10.02}` (-got +want):")
+}
+
+func TestNullEqualsMissing(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONEqual(mt, `{"id": 1, "user": {"name": null}}`, `{"id": 1, "user": {}}`, NullEqualsMissing())
+	}, ``)
+
+	assert(t, func(mt *mockTestingT) bool {
+		return JSONContains(mt, `{"id": 1}`, `{"id": 1, "deleted_at": null}`, NullEqualsMissing())
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return JSONEqual(mt, `{"id": 1, "name": null}`, `{"id": 1, "name": "Alice"}`, NullEqualsMissing())
+		},
+		"`{\"id\": 1, \"name\": null}` (-got +want):")
+}
+
+// BenchmarkUnorderedArrays compares arrays of a few hundred objects given in
+// different orders, with and without some of their fields ignored.
+func BenchmarkUnorderedArrays(b *testing.B) {
+	const n = 400
+	var got, want, approx []interface{}
+	for i := 0; i < n; i++ {
+		j := n - 1 - i
+		got = append(got, map[string]interface{}{"id": float64(i), "sku": fmt.Sprint("sku_", i), "qty": float64(i % 7)})
+		want = append(want, map[string]interface{}{"id": float64(n + i), "sku": fmt.Sprint("sku_", j), "qty": float64(j % 7)})
+		approx = append(approx, map[string]interface{}{"id": float64(j), "sku": fmt.Sprint("sku_", j), "qty": float64(j%7) + 0.1})
+	}
+	gotDoc := map[string]interface{}{"id": "ord_1", "items": got}
+	wantDoc := map[string]interface{}{"id": "ord_1", "items": want}
+	approxDoc := map[string]interface{}{"id": "ord_1", "items": approx}
+
+	b.Run("identical elements", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			mt := &mockTestingT{}
+			if !JSONEqual(mt, gotDoc, gotDoc, UnorderedArrays(), IgnoreJSON("/id")) {
+				b.Fatal(mt.err)
+			}
+		}
+	})
+	b.Run("ignored fields", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			mt := &mockTestingT{}
+			if !JSONEqual(mt, gotDoc, wantDoc, UnorderedArrays(), IgnoreJSON("$.items[*].id")) {
+				b.Fatal(mt.err)
+			}
+		}
+	})
+	b.Run("float tolerance", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			mt := &mockTestingT{}
+			if !JSONEqual(mt, gotDoc, approxDoc, UnorderedArrays(), FloatTolerance(0.5)) {
+				b.Fatal(mt.err)
+			}
+		}
+	})
+}
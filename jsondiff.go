@@ -0,0 +1,171 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// assertJSONEqual is like assertEqual, but for decoded JSON values, reporting
+// the differences in JSON syntax.
+func assertJSONEqual(t testingT, expr func() string, got, want interface{}, opts []cmp.Option) bool {
+	t.Helper()
+	p := newProjector(got, false, collectOptions(t, opts))
+	if len(p.unordered) > 0 {
+		got, want = p.project(got, want, nil)
+	}
+	return reportJSONDiff(t, expr, got, want, unwrapOptions(p.opts), p.indexes)
+}
+
+// reportJSONDiff reports the differences between the decoded JSON values got
+// and want, if any. Unlike assertJSONEqual, the default options are not added.
+// indexes holds the original indexes of the elements of any arrays of want
+// which have been reordered (see projector).
+func reportJSONDiff(t testingT, expr func() string, got, want interface{}, opts []cmp.Option, indexes map[uintptr][]int) bool {
+	t.Helper()
+	diff, err := jsonDiff(got, want, opts, indexes)
+	if err != nil {
+		t.Error("diff error:", err)
+		return false
+	}
+	if diff != "" {
+		t.Error(formatDiff(expr(), "(-got +want):\n", diff))
+		return false
+	}
+	return true
+}
+
+// jsonDiff returns the differences between the decoded JSON values got and
+// want, or "" if they are equal. Each difference is listed under its JSON
+// Pointer, with the value in got prefixed by "-" and the value in want by "+".
+// For example:
+//
+//     /user/name:
+//       - "Alice"
+//       + "Bob"
+func jsonDiff(got, want interface{}, opts []cmp.Option, indexes map[uintptr][]int) (diff string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	r := &jsonReporter{indexes: indexes}
+	if cmp.Equal(got, want, append(opts, cmp.Reporter(r))...) {
+		return "", nil
+	}
+	return r.String(), nil
+}
+
+// jsonReporter is a cmp.Reporter which records the differences between two
+// decoded JSON values.
+type jsonReporter struct {
+	indexes map[uintptr][]int
+	path    cmp.Path
+	diffs   []jsonDifference
+}
+
+// jsonDifference is a difference between two JSON values at a location. The
+// location may differ between them for array elements, as they are aligned to
+// minimize the number of differences.
+type jsonDifference struct {
+	gotPointer, wantPointer string
+	got, want               interface{}
+	hasGot, hasWant         bool
+}
+
+func (r *jsonReporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+func (r *jsonReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+func (r *jsonReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	d := jsonDifference{
+		gotPointer:  jsonPointer(r.path, 0, r.indexes),
+		wantPointer: jsonPointer(r.path, 1, r.indexes),
+	}
+	vx, vy := r.path.Last().Values()
+	if d.hasGot = vx.IsValid() && vx.CanInterface(); d.hasGot {
+		d.got = vx.Interface()
+	}
+	if d.hasWant = vy.IsValid() && vy.CanInterface(); d.hasWant {
+		d.want = vy.Interface()
+	}
+	r.diffs = append(r.diffs, d)
+}
+
+func (r *jsonReporter) String() string {
+	var b strings.Builder
+	for _, d := range r.diffs {
+		pointer := d.gotPointer
+		switch {
+		case !d.hasGot:
+			pointer = d.wantPointer
+		case d.hasWant && d.wantPointer != d.gotPointer:
+			pointer = fmt.Sprintf("%s (want %s)", d.gotPointer, d.wantPointer)
+		}
+		indent := ""
+		if pointer != "" {
+			fmt.Fprintf(&b, "%s:\n", pointer)
+			indent = "  "
+		}
+		if d.hasGot {
+			writeJSONLines(&b, indent+"- ", d.got)
+		}
+		if d.hasWant {
+			writeJSONLines(&b, indent+"+ ", d.want)
+		}
+	}
+	return b.String()
+}
+
+// writeJSONLines writes v as indented JSON, with each line prefixed by prefix.
+func writeJSONLines(b *strings.Builder, prefix string, v interface{}) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fmt.Fprintf(&buf, "%#v\n", v)
+	}
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if line != "" {
+			b.WriteString(prefix + line)
+		}
+	}
+}
+
+// jsonPointer returns the JSON Pointer of the location reached by the cmp
+// path, in the first (side 0) or second (side 1) value being compared. Indexes
+// into the arrays in indexes are replaced by the original indexes.
+func jsonPointer(p cmp.Path, side int, indexes map[uintptr][]int) string {
+	root, steps, ok := jsonSteps(p, side)
+	if !ok {
+		return p.GoString()
+	}
+	var b strings.Builder
+	parent := root
+	for _, step := range steps {
+		key := step.Key
+		if a, ok := parent.([]interface{}); ok && len(a) > 0 {
+			if orig, ok := indexes[reflect.ValueOf(a).Pointer()]; ok {
+				key = orig[key.(int)]
+			}
+		}
+		b.WriteString("/" + pointerEscaper.Replace(fmt.Sprint(key)))
+		parent = step.Node
+	}
+	return b.String()
+}
+
+// pointerEscaper encodes a JSON Pointer token.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
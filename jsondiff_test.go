@@ -0,0 +1,137 @@
+package assert
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJSONDiff(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want string
+		opts      []cmp.Option
+		diff      string
+	}{
+		{
+			name: "equal",
+			got:  `{"a": [1, {"b": null}]}`,
+			want: `{"a": [1, {"b": null}]}`,
+		},
+		{
+			name: "changed value",
+			got:  `{"user": {"name": "Alice"}}`,
+			want: `{"user": {"name": "Bob"}}`,
+			diff: `
+			/user/name:
+			  - "Alice"
+			  + "Bob"
+			`,
+		},
+		{
+			name: "changed type",
+			got:  `{"id": "1"}`,
+			want: `{"id": 1}`,
+			diff: `
+			/id:
+			  - "1"
+			  + 1
+			`,
+		},
+		{
+			name: "missing and extra keys",
+			got:  `{"a": 1, "b": {"c": true}}`,
+			want: `{"a": 1, "d": "<x>"}`,
+			diff: `
+			/b:
+			  - {
+			  -   "c": true
+			  - }
+			/d:
+			  + "<x>"
+			`,
+		},
+		{
+			name: "removed element",
+			got:  `[1, 2, 3]`,
+			want: `[1, 3]`,
+			diff: `
+			/1:
+			  - 2
+			`,
+		},
+		{
+			name: "changed and added elements",
+			got:  `[1, 2]`,
+			want: `[1, 3, 4]`,
+			diff: `
+			/1:
+			  - 2
+			  + 3
+			/2:
+			  + 4
+			`,
+		},
+		{
+			name: "escaped keys",
+			got:  `{"a/b": {"c~d": 1}}`,
+			want: `{"a/b": {"c~d": 2}}`,
+			diff: `
+			/a~1b/c~0d:
+			  - 1
+			  + 2
+			`,
+		},
+		{
+			name: "root",
+			got:  `[]`,
+			want: `{}`,
+			diff: `
+			- []
+			+ {}
+			`,
+		},
+		{
+			name: "ignored location",
+			got:  `{"id": 1, "name": "a"}`,
+			want: `{"id": 2, "name": "b"}`,
+			opts: []cmp.Option{IgnoreJSON("/id")},
+			diff: `
+			/name:
+			  - "a"
+			  + "b"
+			`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got, want interface{}
+			if err := json.Unmarshal([]byte(tt.got), &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			diff, err := jsonDiff(got, want, unwrapOptions(tt.opts), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertEQ(t, diff, dedent(tt.diff))
+		})
+	}
+}
+
+// dedent removes the leading newline and the indentation of each line of a
+// raw string literal indented to match the surrounding code.
+func dedent(s string) string {
+	if s == "" {
+		return ""
+	}
+	lines := strings.Split(strings.TrimPrefix(s, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimLeft(l, "\t")
+	}
+	return strings.Join(lines, "\n")
+}
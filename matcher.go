@@ -0,0 +1,592 @@
+package assert
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Matcher matches values. A Matcher may be used in place of a value anywhere
+// inside the want value passed to Equal, NotEqual, JSONEqual, JSONPath and the
+// other assertions comparing values, in which case the corresponding value in
+// got must match it. For example:
+//
+//     assert.Equal(t, resp, map[string]interface{}{
+//         "id":    assert.Regex("^ord_"),
+//         "total": 1250,
+//     })
+//
+// Where a field has a concrete type rather than interface{}, a string field
+// may be matched using StringMatching or AnyString:
+//
+//     assert.Equal(t, order, Order{ID: assert.AnyString(), Total: 1250})
+type Matcher interface {
+	// Match reports whether v matches.
+	Match(v interface{}) bool
+
+	// Describe returns a description of the values which match, for use in
+	// failure messages.
+	Describe() string
+}
+
+// matcherTokenPrefix begins the placeholder strings which stand in for
+// matchers. They are designed to be unlikely to collide with real values.
+const matcherTokenPrefix = "<<assert.Matcher:"
+
+// StringMatching returns a placeholder string which, when it appears in a want
+// value, matches the values m matches. It allows matchers to be used in fields
+// of type string. The placeholder describes how m was created, so m must be
+// one of this package's matchers (built, in the case of AnyOf, AllOf and Not,
+// only from others), and StringMatching panics otherwise. Other matchers may
+// still be used in fields of interface type.
+func StringMatching(m Matcher) string {
+	if m, ok := m.(*matcher); ok && m.spec != nil {
+		return m.token
+	}
+	panic(fmt.Sprintf("assert: StringMatching: %s is not one of this package's matchers", m.Describe()))
+}
+
+// AnyString returns a placeholder string which, when it appears in a want
+// value, matches any string. See StringMatching.
+func AnyString() string {
+	return anyString().token
+}
+
+func anyString() *matcher {
+	return newMatcher(&matcherSpec{Fn: "AnyString"}, "AnyString()", func(v interface{}) bool {
+		_, ok := v.(string)
+		return ok
+	})
+}
+
+// matcher is the Matcher returned by this package's constructors. It's
+// represented in want values converted to JSON, and in diffs, by its
+// placeholder string.
+type matcher struct {
+	// spec is the constructor and arguments the matcher was created with, or
+	// nil if it was created from matchers not belonging to this package.
+	spec  *matcherSpec
+	desc  string
+	match func(v interface{}) bool
+
+	// token is the matcher's placeholder string. It holds desc and spec, so
+	// that the matcher can be recreated from it (see parseMatcherToken).
+	token string
+}
+
+// matcherSpec identifies a matcher created by this package by its constructor
+// and arguments. Which arguments are set depends on the constructor.
+type matcherSpec struct {
+	Fn       string         `json:"fn"`
+	Matchers []*matcherSpec `json:"matchers,omitempty"`
+	String   string         `json:"string,omitempty"`
+	Int      int            `json:"int,omitempty"`
+	Values   []matcherValue `json:"values,omitempty"`
+}
+
+// matcherValue is an argument of BetweenValues, recorded as the kind of value
+// compareValues treats it as, and its text.
+type matcherValue struct {
+	Kind string `json:"kind"`
+	Text string `json:"text,omitempty"`
+}
+
+// matcherToken is the content of a placeholder string.
+type matcherToken struct {
+	Desc string       `json:"desc"`
+	Spec *matcherSpec `json:"spec,omitempty"`
+}
+
+// newMatcher returns a matcher created from spec, which is nil if the matcher
+// was created from matchers not belonging to this package, desc and match.
+func newMatcher(spec *matcherSpec, desc string, match func(v interface{}) bool) *matcher {
+	b, err := json.Marshal(matcherToken{Desc: desc, Spec: spec})
+	if err != nil {
+		panic(err)
+	}
+	token := matcherTokenPrefix + base64.RawURLEncoding.EncodeToString(b) + ">>"
+	return &matcher{spec: spec, desc: desc, match: match, token: token}
+}
+
+func (m *matcher) Match(v interface{}) bool { return m.match(v) }
+func (m *matcher) Describe() string         { return m.desc }
+
+// String returns the matcher's placeholder string, so that cmp formats it as
+// such in diffs and describeMatchers can replace it with the description.
+func (m *matcher) String() string { return m.token }
+
+// MarshalJSON encodes the matcher as its placeholder string.
+func (m *matcher) MarshalJSON() ([]byte, error) {
+	if m.spec == nil {
+		return nil, fmt.Errorf("assert: %s is not one of this package's matchers and can't be converted to JSON", m.desc)
+	}
+	return json.Marshal(m.token)
+}
+
+// parseMatcherToken parses the placeholder string s, reporting false if it
+// isn't one.
+func parseMatcherToken(s string) (matcherToken, bool) {
+	var token matcherToken
+	if !strings.HasPrefix(s, matcherTokenPrefix) || !strings.HasSuffix(s, ">>") {
+		return token, false
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s[len(matcherTokenPrefix) : len(s)-len(">>")])
+	if err != nil || json.Unmarshal(b, &token) != nil {
+		return token, false
+	}
+	return token, true
+}
+
+// parseMatcher recreates the matcher represented by the placeholder string s,
+// reporting false if s isn't the placeholder of one of this package's
+// matchers.
+func parseMatcher(s string) (*matcher, bool) {
+	token, ok := parseMatcherToken(s)
+	if !ok || token.Spec == nil {
+		return nil, false
+	}
+	m, ok := token.Spec.matcher()
+	if !ok {
+		return nil, false
+	}
+	m.desc, m.token = token.Desc, s
+	return m, true
+}
+
+// matcher recreates the matcher s identifies, reporting false if s is
+// invalid.
+func (s *matcherSpec) matcher() (m *matcher, ok bool) {
+	// The constructors panic given invalid arguments, e.g. a pattern which
+	// doesn't compile.
+	defer func() {
+		if recover() != nil {
+			m, ok = nil, false
+		}
+	}()
+	var ms []Matcher
+	for _, spec := range s.Matchers {
+		m, ok := spec.matcher()
+		if !ok {
+			return nil, false
+		}
+		ms = append(ms, m)
+	}
+	switch s.Fn {
+	case "AnyString":
+		return anyString(), true
+	case "AnyOf":
+		return AnyOf(ms...).(*matcher), true
+	case "AllOf":
+		return AllOf(ms...).(*matcher), true
+	case "Not":
+		if len(ms) != 1 {
+			return nil, false
+		}
+		return Not(ms[0]).(*matcher), true
+	case "Regex":
+		return Regex(s.String).(*matcher), true
+	case "HasPrefix":
+		return HasPrefix(s.String).(*matcher), true
+	case "BetweenValues":
+		if len(s.Values) != 2 {
+			return nil, false
+		}
+		lo, ok1 := s.Values[0].value()
+		hi, ok2 := s.Values[1].value()
+		if !ok1 || !ok2 {
+			return nil, false
+		}
+		return BetweenValues(lo, hi).(*matcher), true
+	case "Len":
+		return Len(s.Int).(*matcher), true
+	case "IsA":
+		typ, ok := matcherType(s.Int)
+		if !ok {
+			return nil, false
+		}
+		return isA(typ), true
+	}
+	return nil, false
+}
+
+// newMatcherValue records v, an argument of BetweenValues.
+func newMatcherValue(v interface{}) matcherValue {
+	if t, ok := v.(time.Time); ok {
+		return matcherValue{Kind: "time", Text: t.Format(time.RFC3339Nano)}
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.String {
+		return matcherValue{Kind: "string", Text: rv.String()}
+	}
+	switch numberKind(rv) {
+	case reflect.Int64:
+		return matcherValue{Kind: "int", Text: strconv.FormatInt(rv.Int(), 10)}
+	case reflect.Uint64:
+		return matcherValue{Kind: "uint", Text: strconv.FormatUint(rv.Uint(), 10)}
+	case reflect.Float64:
+		return matcherValue{Kind: "float", Text: strconv.FormatFloat(rv.Float(), 'g', -1, 64)}
+	}
+	// compareValues can't compare v with anything.
+	return matcherValue{Kind: "none"}
+}
+
+// value returns a value which compareValues treats as it did the value v was
+// recorded from.
+func (v matcherValue) value() (interface{}, bool) {
+	var (
+		result interface{}
+		err    error
+	)
+	switch v.Kind {
+	case "time":
+		result, err = time.Parse(time.RFC3339Nano, v.Text)
+	case "string":
+		result = v.Text
+	case "int":
+		result, err = strconv.ParseInt(v.Text, 10, 64)
+	case "uint":
+		result, err = strconv.ParseUint(v.Text, 10, 64)
+	case "float":
+		result, err = strconv.ParseFloat(v.Text, 64)
+	case "none":
+	default:
+		return nil, false
+	}
+	return result, err == nil
+}
+
+var (
+	matcherTypesMu sync.Mutex
+
+	// matcherTypes holds the types given to IsA, so that placeholders can
+	// identify them by index. Unlike the matchers themselves, there can only be
+	// as many as the types IsA is instantiated with in the program.
+	matcherTypes       []reflect.Type
+	matcherTypeIndexes = make(map[reflect.Type]int)
+)
+
+// matcherTypeIndex returns the index of typ in matcherTypes, adding it if
+// necessary.
+func matcherTypeIndex(typ reflect.Type) int {
+	matcherTypesMu.Lock()
+	defer matcherTypesMu.Unlock()
+	i, ok := matcherTypeIndexes[typ]
+	if !ok {
+		i = len(matcherTypes)
+		matcherTypes = append(matcherTypes, typ)
+		matcherTypeIndexes[typ] = i
+	}
+	return i
+}
+
+// matcherType returns the type at index i in matcherTypes.
+func matcherType(i int) (reflect.Type, bool) {
+	matcherTypesMu.Lock()
+	defer matcherTypesMu.Unlock()
+	if i < 0 || i >= len(matcherTypes) {
+		return nil, false
+	}
+	return matcherTypes[i], true
+}
+
+// AnyOf returns a Matcher which matches values matching any of ms.
+func AnyOf(ms ...Matcher) Matcher {
+	return newMatcher(combinedSpec("AnyOf", ms), describeCall("AnyOf", ms), func(v interface{}) bool {
+		for _, m := range ms {
+			if m.Match(v) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// AllOf returns a Matcher which matches values matching all of ms.
+func AllOf(ms ...Matcher) Matcher {
+	return newMatcher(combinedSpec("AllOf", ms), describeCall("AllOf", ms), func(v interface{}) bool {
+		for _, m := range ms {
+			if !m.Match(v) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Not returns a Matcher which matches values not matching m.
+func Not(m Matcher) Matcher {
+	return newMatcher(combinedSpec("Not", []Matcher{m}), describeCall("Not", []Matcher{m}), func(v interface{}) bool {
+		return !m.Match(v)
+	})
+}
+
+// Regex returns a Matcher which matches strings matching the regular
+// expression pattern. It panics if the pattern is invalid.
+func Regex(pattern string) Matcher {
+	re := regexp.MustCompile(pattern)
+	return newMatcher(&matcherSpec{Fn: "Regex", String: pattern}, fmt.Sprintf("Regex(%q)", pattern), func(v interface{}) bool {
+		s, ok := v.(string)
+		return ok && re.MatchString(s)
+	})
+}
+
+// HasPrefix returns a Matcher which matches strings beginning with prefix.
+func HasPrefix(prefix string) Matcher {
+	return newMatcher(&matcherSpec{Fn: "HasPrefix", String: prefix}, fmt.Sprintf("HasPrefix(%q)", prefix), func(v interface{}) bool {
+		s, ok := v.(string)
+		return ok && strings.HasPrefix(s, prefix)
+	})
+}
+
+// BetweenValues returns a Matcher which matches values between lo and hi,
+// inclusive. The values may be numbers of any type (including JSON numbers),
+// strings, time.Durations or time.Times. (Between is the equivalent assertion.)
+func BetweenValues(lo, hi interface{}) Matcher {
+	spec := &matcherSpec{Fn: "BetweenValues", Values: []matcherValue{newMatcherValue(lo), newMatcherValue(hi)}}
+	desc := fmt.Sprintf("BetweenValues(%s, %s)", fmtVal(lo), fmtVal(hi))
+	return newMatcher(spec, desc, func(v interface{}) bool {
+		c1, ok1 := compareValues(v, lo)
+		c2, ok2 := compareValues(v, hi)
+		return ok1 && ok2 && c1 >= 0 && c2 <= 0
+	})
+}
+
+// Len returns a Matcher which matches strings, arrays, slices, maps and
+// channels of length n.
+func Len(n int) Matcher {
+	return newMatcher(&matcherSpec{Fn: "Len", Int: n}, fmt.Sprintf("Len(%d)", n), func(v interface{}) bool {
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.String, reflect.Array, reflect.Slice, reflect.Map, reflect.Chan:
+			return rv.Len() == n
+		}
+		return false
+	})
+}
+
+// IsA returns a Matcher which matches values of type T. Values compared as
+// JSON have JSON types, e.g. float64 for numbers.
+func IsA[T any]() Matcher {
+	return isA(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// isA returns the Matcher IsA returns for typ, which matches values v for
+// which v.(T) would succeed.
+func isA(typ reflect.Type) *matcher {
+	spec := &matcherSpec{Fn: "IsA", Int: matcherTypeIndex(typ)}
+	return newMatcher(spec, fmt.Sprintf("IsA[%v]()", typ), func(v interface{}) bool {
+		if v == nil {
+			return false
+		}
+		if typ.Kind() == reflect.Interface {
+			return reflect.TypeOf(v).Implements(typ)
+		}
+		return reflect.TypeOf(v) == typ
+	})
+}
+
+// combinedSpec returns the spec of the matcher created by fn from ms, or nil
+// if any of ms isn't one of this package's matchers.
+func combinedSpec(fn string, ms []Matcher) *matcherSpec {
+	spec := &matcherSpec{Fn: fn}
+	for _, m := range ms {
+		m, ok := m.(*matcher)
+		if !ok || m.spec == nil {
+			return nil
+		}
+		spec.Matchers = append(spec.Matchers, m.spec)
+	}
+	return spec
+}
+
+// describeCall describes a matcher taking other matchers as arguments.
+func describeCall(name string, ms []Matcher) string {
+	descs := make([]string, len(ms))
+	for i, m := range ms {
+		descs[i] = m.Describe()
+	}
+	return name + "(" + strings.Join(descs, ", ") + ")"
+}
+
+// compareValues compares two numbers, strings, time.Durations or time.Times,
+// returning -1, 0 or 1 as x is less than, equal to or greater than y. It
+// reports false if they can't be compared. Numbers of different types are
+// compared by value: integers exactly, whatever their size and signedness, and
+// as float64s only if either is a float.
+func compareValues(x, y interface{}) (int, bool) {
+	if tx, ok := x.(time.Time); ok {
+		ty, ok := y.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case tx.Before(ty):
+			return -1, true
+		case tx.After(ty):
+			return 1, true
+		}
+		return 0, true
+	}
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	if vx.Kind() == reflect.String {
+		if vy.Kind() != reflect.String {
+			return 0, false
+		}
+		return compareOrdered(vx.String(), vy.String()), true
+	}
+	kx, ky := numberKind(vx), numberKind(vy)
+	switch {
+	case kx == reflect.Invalid || ky == reflect.Invalid:
+		return 0, false
+	case kx == reflect.Float64 || ky == reflect.Float64:
+		fx, _ := toFloat(x)
+		fy, _ := toFloat(y)
+		return compareOrdered(fx, fy), true
+	case kx == reflect.Int64 && ky == reflect.Int64:
+		return compareOrdered(vx.Int(), vy.Int()), true
+	case kx == reflect.Uint64 && ky == reflect.Uint64:
+		return compareOrdered(vx.Uint(), vy.Uint()), true
+	case kx == reflect.Int64:
+		if vx.Int() < 0 {
+			return -1, true
+		}
+		return compareOrdered(uint64(vx.Int()), vy.Uint()), true
+	default:
+		if vy.Int() < 0 {
+			return 1, true
+		}
+		return compareOrdered(vx.Uint(), uint64(vy.Int())), true
+	}
+}
+
+// numberKind returns reflect.Int64, reflect.Uint64 or reflect.Float64 for a
+// signed integer, unsigned integer or float of any size, or 0 (reflect.Invalid)
+// if v isn't a number.
+func numberKind(v reflect.Value) reflect.Kind {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint64
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return reflect.Invalid
+}
+
+// compareOrdered returns -1, 0 or 1 as x is less than, equal to or greater
+// than y.
+func compareOrdered[T int64 | uint64 | float64 | string](x, y T) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// toFloat converts a number of any type to a float64.
+func toFloat(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// matcherCache holds the matchers recreated from placeholder strings during
+// an assertion, so that each is parsed once.
+type matcherCache map[string]*matcher
+
+// asMatcher returns the Matcher v represents, if any: either a Matcher or a
+// placeholder string created by StringMatching.
+func (c matcherCache) asMatcher(v interface{}) (Matcher, bool) {
+	switch v := v.(type) {
+	case Matcher:
+		return v, true
+	case string:
+		if !strings.HasPrefix(v, matcherTokenPrefix) {
+			return nil, false
+		}
+		m, ok := c[v]
+		if !ok {
+			m, _ = parseMatcher(v)
+			c[v] = m
+		}
+		return m, m != nil
+	}
+	return nil, false
+}
+
+// newMatcherComparer returns the option added to the options of every
+// assertion, so that matchers in want are compared against the corresponding
+// values in got. Matchers are compared with each other by their placeholders,
+// if they have them.
+func newMatcherComparer() cmp.Option {
+	c := make(matcherCache)
+	return cmp.FilterValues(func(x, y interface{}) bool {
+		_, okx := c.asMatcher(x)
+		_, oky := c.asMatcher(y)
+		return okx || oky
+	}, cmp.Comparer(func(x, y interface{}) bool {
+		mx, okx := c.asMatcher(x)
+		my, oky := c.asMatcher(y)
+		switch {
+		case okx && oky:
+			return reflect.DeepEqual(placeholderOf(x, mx), placeholderOf(y, my))
+		case oky:
+			return my.Match(x)
+		}
+		return mx.Match(y)
+	}))
+}
+
+// placeholderOf returns the placeholder string of m, the Matcher v represents,
+// or v itself if m has none.
+func placeholderOf(v interface{}, m Matcher) interface{} {
+	if m, ok := m.(*matcher); ok {
+		return m.token
+	}
+	return v
+}
+
+// matcherPlaceholder matches the placeholder of a matcher, as formatted in a
+// diff by cmp or as JSON. The placeholder itself is one of the submatches.
+var matcherPlaceholder = func() *regexp.Regexp {
+	token := `(` + regexp.QuoteMeta(matcherTokenPrefix) + `[A-Za-z0-9_-]*>>)`
+	return regexp.MustCompile(`s"` + token + `"|string\("` + token + `"\)|"` + token + `"`)
+}()
+
+// describeMatchers replaces the placeholders of matchers in a diff with their
+// descriptions.
+func describeMatchers(diff string) string {
+	if !strings.Contains(diff, matcherTokenPrefix) {
+		return diff
+	}
+	return matcherPlaceholder.ReplaceAllStringFunc(diff, func(s string) string {
+		var placeholder string
+		for _, sub := range matcherPlaceholder.FindStringSubmatch(s)[1:] {
+			if sub != "" {
+				placeholder = sub
+			}
+		}
+		if token, ok := parseMatcherToken(placeholder); ok {
+			return token.Desc
+		}
+		return s
+	})
+}
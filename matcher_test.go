@@ -0,0 +1,160 @@
+package assert
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMatchers(t *testing.T) {
+	tests := []struct {
+		m    Matcher
+		v    interface{}
+		want bool
+	}{
+		{Regex("^ord_[0-9]+$"), "ord_123", true},
+		{Regex("^ord_[0-9]+$"), "usr_123", false},
+		{Regex("^ord_"), 1, false},
+		{HasPrefix("ord_"), "ord_123", true},
+		{HasPrefix("ord_"), "usr_123", false},
+		{BetweenValues(1, 10), 5, true},
+		{BetweenValues(1, 10), 10.0, true},
+		{BetweenValues(1, 10), uint8(11), false},
+		{BetweenValues(1, 10), "5", false},
+		{BetweenValues("b", "d"), "c", true},
+		{BetweenValues(time.Second, time.Minute), time.Hour, false},
+		{BetweenValues(time.Unix(0, 0), time.Unix(10, 0)), time.Unix(5, 0), true},
+		{BetweenValues(int64(1<<62+1), int64(1<<62+2)), int64(1 << 62), false},
+		{BetweenValues(time.Duration(1<<60+1), time.Duration(1<<60+2)), time.Duration(1 << 60), false},
+		{Len(2), []int{1, 2}, true},
+		{Len(2), "ab", true},
+		{Len(2), map[string]int{"a": 1}, false},
+		{Len(2), 2, false},
+		{IsA[string](), "a", true},
+		{IsA[string](), 1, false},
+		{IsA[error](), errors.New("x"), true},
+		{Not(IsA[string]()), 1, true},
+		{AnyOf(IsA[string](), Len(2)), []int{1, 2}, true},
+		{AnyOf(IsA[string](), Len(2)), []int{1}, false},
+		{AllOf(HasPrefix("a"), Len(2)), "ab", true},
+		{AllOf(HasPrefix("a"), Len(2)), "abc", false},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Match(tt.v); got != tt.want {
+			t.Errorf("%s.Match(%#v) = %v, want %v", tt.m.Describe(), tt.v, got, tt.want)
+		}
+	}
+
+	assertEQ(t, AnyOf(Not(Regex("a+")), BetweenValues(1, "z"), IsA[int]()).Describe(),
+		`AnyOf(Not(Regex("a+")), BetweenValues(1, "z"), IsA[int]())`)
+}
+
+func TestMatchersInWant(t *testing.T) {
+	type order struct {
+		ID    string
+		Total int
+		Meta  interface{}
+	}
+	got := order{ID: "ord_123", Total: 1250, Meta: []string{"a", "b"}}
+
+	assert(t, func(mt *mockTestingT) bool {
+		return Equal(mt, got, order{ID: AnyString(), Total: 1250, Meta: Len(2)})
+	}, ``)
+
+	t.Run("failure", func(t *testing.T) {
+		mt := &mockTestingT{}
+		Equal(mt, got, order{ID: StringMatching(HasPrefix("usr_")), Total: 1250, Meta: Len(2)})
+		if !strings.Contains(mt.err, `HasPrefix("usr_"),`) {
+			t.Errorf("got %s, want the matcher's description in the diff", mt.err)
+		}
+	})
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return NotEqual(mt, got, order{ID: AnyString(), Total: 1250, Meta: Len(2)})
+		},
+		`got should not equal`)
+
+	t.Run("JSON", func(t *testing.T) {
+		resp := `{"id": "ord_123", "total": 1250, "items": [{"sku": "a"}]}`
+
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONEqual(mt, resp, map[string]interface{}{
+				"id":    Regex("^ord_"),
+				"total": BetweenValues(1000, 2000),
+				"items": Len(1),
+			})
+		}, ``)
+
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONPath(mt, resp, "items[0].sku", IsA[string]())
+		}, ``)
+
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONContains(mt, resp, map[string]interface{}{"id": AnyString()})
+		}, ``)
+
+		assert(t,
+			func(mt *mockTestingT) bool {
+				return JSONEqual(mt, resp, map[string]interface{}{
+					"id":    Regex("^usr_"),
+					"total": BetweenValues(1000, 2000),
+					"items": Len(1),
+				})
+			},
+			"resp (-got +want):\n"+
+				"/id:\n"+
+				"  - \"ord_123\"\n"+
+				"  + Regex(\"^usr_\")")
+	})
+}
+
+func TestMatcherPlaceholders(t *testing.T) {
+	assertEQ(t, StringMatching(Regex("^ord_")), StringMatching(Regex("^ord_")))
+	if StringMatching(Regex("^ord_")) == StringMatching(Regex("^usr_")) {
+		t.Error("different matchers share a placeholder")
+	}
+
+	// Placeholders describe their matchers, so that they can be recreated.
+	tests := []struct {
+		m  Matcher
+		vs []interface{}
+	}{
+		{AnyOf(Not(Regex("a+")), BetweenValues(1, "z"), IsA[int]()), []interface{}{"a", "b", 1, 1.5}},
+		{AllOf(HasPrefix("a"), Len(2)), []interface{}{"ab", "abc"}},
+		{BetweenValues(time.Second, time.Minute), []interface{}{time.Second, time.Hour, 1.0}},
+		{BetweenValues(time.Unix(0, 0), time.Unix(10, 0)), []interface{}{time.Unix(5, 0), time.Unix(11, 0)}},
+		{BetweenValues(uint64(1<<63+1), 1e20), []interface{}{uint64(1 << 63), uint64(1<<63 + 1)}},
+		{IsA[error](), []interface{}{errors.New("x"), "x"}},
+	}
+	for _, tt := range tests {
+		m, ok := parseMatcher(StringMatching(tt.m))
+		if !ok {
+			t.Errorf("%s: can't recreate from placeholder", tt.m.Describe())
+			continue
+		}
+		assertEQ(t, m.Describe(), tt.m.Describe())
+		for _, v := range tt.vs {
+			if got, want := m.Match(v), tt.m.Match(v); got != want {
+				t.Errorf("recreated %s.Match(%#v) = %v, want %v", tt.m.Describe(), v, got, want)
+			}
+		}
+	}
+
+	t.Run("other matchers", func(t *testing.T) {
+		defer func() {
+			assertEQ(t, recover(), "assert: StringMatching: AnyOf(stringLen) is not one of this package's matchers")
+		}()
+		StringMatching(AnyOf(&stringLen{n: 3}))
+	})
+}
+
+type stringLen struct{ n int }
+
+func (m *stringLen) Match(v interface{}) bool {
+	s, ok := v.(string)
+	return ok && len(s) == m.n
+}
+
+func (m *stringLen) Describe() string { return "stringLen" }
@@ -0,0 +1,79 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// annotation is a message to be included in the failure output of an
+// assertion. It is passed to assertions as a cmp.Option, but is removed before
+// the options reach cmp, which doesn't accept it.
+type annotation struct {
+	cmp.Option
+	msg string
+}
+
+// Msg returns an option which adds the formatted message to the failure output
+// of the assertion it is passed to. It is useful for identifying the failing
+// case in table-driven tests:
+//
+//     for _, tt := range tests {
+//         assert.Equal(t, Total(tt.order), tt.want, assert.Msg("order %d", tt.order.ID))
+//     }
+//
+// To add a message to assertions which don't accept options, wrap t using
+// WithOptions.
+func Msg(format string, args ...interface{}) cmp.Option {
+	return newAnnotation(fmt.Sprintf(format, args...))
+}
+
+// Context returns an option which adds key/value pairs to the failure output of
+// the assertion it is passed to, in the form `key=value`. For example:
+//
+//     assert.Equal(t, got, want, assert.Context("order", id, "row", i))
+func Context(keysAndValues ...interface{}) cmp.Option {
+	pairs := make([]string, 0, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 == len(keysAndValues) {
+			pairs = append(pairs, fmt.Sprintf("%v=<missing>", keysAndValues[i]))
+			break
+		}
+		pairs = append(pairs, fmt.Sprintf("%v=%s", keysAndValues[i], fmtVal(keysAndValues[i+1])))
+	}
+	return newAnnotation(strings.Join(pairs, " "))
+}
+
+func newAnnotation(msg string) cmp.Option {
+	return annotation{msg: msg}
+}
+
+// annotate removes any annotations from opts and, if there were any, wraps t
+// so that they are included in its failure output.
+func annotate(t testingT, opts []cmp.Option) (testingT, []cmp.Option) {
+	msgs, rest := splitAnnotations(opts)
+	if len(msgs) == 0 {
+		return t, opts
+	}
+	return &Scoped{testingT: t, msgs: msgs}, rest
+}
+
+// splitAnnotations separates the messages of the annotations in opts from the
+// remaining options.
+func splitAnnotations(opts []cmp.Option) (msgs []string, rest []cmp.Option) {
+	for _, opt := range opts {
+		if nested, ok := opt.(cmp.Options); ok {
+			m, r := splitAnnotations(nested)
+			msgs = append(msgs, m...)
+			rest = append(rest, cmp.Options(r))
+			continue
+		}
+		if a, ok := opt.(annotation); ok {
+			msgs = append(msgs, a.msg)
+			continue
+		}
+		rest = append(rest, opt)
+	}
+	return msgs, rest
+}
@@ -0,0 +1,85 @@
+package assert
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestMsg(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		return Equal(mt, 1, 1, Msg("order %d", 1))
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			total := 10
+			return Equal(mt, total, 20, Msg("order %d", 1))
+		},
+		`order 1: total (-got +want):`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			out := []int{1, 2}
+			return Contains(mt, out, 3, cmp.Options{Msg("row %d", 2)})
+		},
+		`row 2: out does not contain:`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			type item struct{ ID, Qty int }
+			items := []item{{ID: 1, Qty: 1}}
+			return ContainsAll(mt, items, []item{{ID: 2, Qty: 1}}, Msg("first"), cmpopts.IgnoreFields(item{}, "ID"), Msg("second"))
+		},
+		``)
+}
+
+func TestContext(t *testing.T) {
+	assert(t,
+		func(mt *mockTestingT) bool {
+			total := 10
+			return Equal(mt, total, 20, Context("order", 1, "customer", "alice"))
+		},
+		`order=1 customer="alice": total (-got +want):`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			total := 10
+			return Equal(mt, total, 20, Context("order"))
+		},
+		`order=<missing>: total (-got +want):`)
+}
+
+func TestMsgWithOptions(t *testing.T) {
+	assert(t,
+		func(mt *mockTestingT) bool {
+			at := WithOptions(mt, Msg("order %d", 1))
+			enabled := false
+			return True(at, enabled)
+		},
+		`order 1: enabled (-got +want):`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			at := WithOptions(mt, Msg("order %d", 1))
+			total := 10
+			return Equal(at, total, 20, Msg("line %d", 2))
+		},
+		`order 1: line 2: total (-got +want):`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			a := New(mt, Msg("order %d", 1))
+			var val string
+			return a.NotEmpty(val)
+		},
+		`order 1: val was empty`)
+}
+
+func TestMsgNotPassedToCmp(t *testing.T) {
+	opts := withDefaults(nil, []cmp.Option{Msg("a"), cmp.Options{Context("b", 1)}})
+	msgs, _ := splitAnnotations(opts)
+	assertEQ(t, len(msgs), 0)
+	assertEQ(t, cmp.Equal(1, 1, opts...), true)
+}
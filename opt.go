@@ -1,16 +1,201 @@
 package assert
 
-import "github.com/google/go-cmp/cmp"
+import (
+	"errors"
+	"fmt"
+	"reflect"
 
-// Ignore configures assert to ignore the specified field paths when testing
-// equality. Nested paths may be expressed with periods (e.g. "User.ID").
+	"github.com/google/go-cmp/cmp"
+)
+
+// Ignore configures assert to ignore the locations matching the specified path
+// patterns when testing equality. Nested fields are separated by periods (e.g.
+// "User.ID"), and patterns may select elements of slices and entries of maps
+// and use wildcards, as in "Items[*].CreatedAt", "Labels{env}" or
+// "**.UpdatedAt":
+//
+//     Name     the struct field Name
+//     *        any struct field
+//     **       any number of fields, elements and entries, including none
+//     [3]      the element at index 3 of a slice or array
+//     [*]      any element of a slice or array
+//     {key}    the entry of a map whose key formats (as by fmt.Sprint) as key
+//     {*}      any entry of a map
+//
+// Pointers are followed implicitly, as are elements and entries not mentioned
+// before a field name, so "Items.CreatedAt" is equivalent to
+// "Items[*].CreatedAt". Ignore panics if a pattern is invalid.
+//
+// So that misspelled patterns are caught, Equal and NotEqual fail if a pattern
+// passed to them can't match anything in the type of the values being
+// compared, or if it names particular elements or entries and none of them
+// were compared while others were. The failure lists the most similar paths.
+// Patterns in options given to WithOptions or RegisterOptions aren't checked,
+// as they may be intended for other types. To ignore locations in JSON values,
+// use IgnoreJSON. Like Msg, the option is understood only by the assertions
+// in this package, and can't be passed to cmp directly.
 func Ignore(paths ...string) cmp.Option {
-	var result cmp.Options
-	for _, path := range paths {
-		path := path
-		result = append(result, cmp.FilterPath(func(p cmp.Path) bool {
-			return p.String() == path
-		}, cmp.Ignore()))
+	patterns := mustParsePathPatterns("Ignore", paths)
+	filter := cmp.FilterPath(func(p cmp.Path) bool {
+		steps := pathSteps(p)
+		for _, pattern := range patterns {
+			if pattern.matches(steps) {
+				return true
+			}
+		}
+		return false
+	}, cmp.Ignore())
+	return pathOption{Option: filter, fn: "Ignore", patterns: patterns}
+}
+
+// Only configures assert to compare only the locations matching the specified
+// path patterns (see Ignore) and the values within them, ignoring everything
+// else. For example, to compare only the totals of a slice of orders:
+//
+//     assert.Equal(t, orders, want, assert.Only("[*].Total"))
+//
+// As with Ignore, an assertion fails if a pattern can't match anything.
+func Only(paths ...string) cmp.Option {
+	patterns := mustParsePathPatterns("Only", paths)
+	filter := cmp.FilterPath(func(p cmp.Path) bool {
+		return !selected(patterns, pathSteps(p))
+	}, cmp.Ignore())
+	return pathOption{Option: filter, fn: "Only", patterns: patterns}
+}
+
+// CompareOnly is like Only, but applies wherever a value of the same type as
+// typ is compared, with the patterns relative to that value. For example, to
+// compare only the IDs and names of users, wherever they appear:
+//
+//     assert.Equal(t, got, want, assert.CompareOnly(User{}, "ID", "Name"))
+//
+// It panics if a pattern is invalid or can't match anything in the type.
+func CompareOnly(typ interface{}, paths ...string) cmp.Option {
+	t := reflect.TypeOf(typ)
+	if t == nil {
+		panic("assert: CompareOnly: typ must not be nil")
+	}
+	patterns := mustParsePathPatterns("CompareOnly", paths)
+	for _, pattern := range patterns {
+		if !pattern.possibleIn(t) {
+			err := unmatchedPatternError("CompareOnly", pattern, t.String(), typePaths(t, len(pattern.tokens)+1))
+			panic("assert: " + err.Error())
+		}
+	}
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		// Find the innermost value of type t containing this location.
+		for i := len(p) - 2; i >= 0; i-- {
+			if p.Index(i).Type() == t {
+				return !selected(patterns, pathSteps(p[i+1:]))
+			}
+		}
+		return false
+	}, cmp.Ignore())
+}
+
+// selected reports whether the location reached by steps is, contains or is
+// within a location matched by one of patterns.
+func selected(patterns []*pathPattern, steps []pathStep) bool {
+	for _, pattern := range patterns {
+		if pattern.matchesAncestor(steps) || pattern.matchesDescendant(steps) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorMode determines how errors are compared for equality.
+type ErrorMode int
+
+const (
+	// ErrorsByMessage considers two errors equal if their messages are equal.
+	// This is the default.
+	ErrorsByMessage ErrorMode = iota
+
+	// ErrorsByIs considers two errors equal if either matches the other, as
+	// reported by errors.Is.
+	ErrorsByIs
+
+	// ErrorsByTypeAndMessage considers two errors equal if they have the same
+	// dynamic type and their messages are equal.
+	ErrorsByTypeAndMessage
+
+	// ErrorsByStructure considers two errors equal if they have the same
+	// dynamic type and all of their fields, exported or not, are equal.
+	ErrorsByStructure
+)
+
+// errorComparers holds the option for each ErrorMode. They are created once so
+// that they can be recognised among the options passed to an assertion.
+var errorComparers = [...]cmp.Option{
+	ErrorsByMessage: errorComparer(func(x, y error) bool {
+		return x.Error() == y.Error()
+	}),
+	ErrorsByIs: errorComparer(func(x, y error) bool {
+		return errors.Is(x, y) || errors.Is(y, x)
+	}),
+	ErrorsByTypeAndMessage: errorComparer(func(x, y error) bool {
+		return reflect.TypeOf(x) == reflect.TypeOf(y) && x.Error() == y.Error()
+	}),
+	ErrorsByStructure: errorComparer(func(x, y error) bool {
+		exportAll := cmp.Exporter(func(reflect.Type) bool { return true })
+		return reflect.TypeOf(x) == reflect.TypeOf(y) && cmp.Equal(x, y, exportAll)
+	}),
+}
+
+// errorComparer returns an option comparing any two non-nil errors using
+// equal, even if they are of different types.
+func errorComparer(equal func(x, y error) bool) cmp.Option {
+	return cmp.FilterValues(func(x, y interface{}) bool {
+		_, okx := x.(error)
+		_, oky := y.(error)
+		return okx && oky
+	}, cmp.Comparer(func(x, y interface{}) bool {
+		return equal(x.(error), y.(error))
+	}))
+}
+
+// CompareErrors configures how errors are compared for equality, replacing the
+// default comparison by message. It can be passed to an assertion or
+// registered with RegisterOptions; an option passed to an assertion takes
+// precedence over a registered one. For example, to compare typed errors
+// including their fields:
+//
+//     assert.Equal(t, err, &NotFoundError{ID: 1}, assert.CompareErrors(assert.ErrorsByStructure))
+func CompareErrors(mode ErrorMode) cmp.Option {
+	if mode < 0 || int(mode) >= len(errorComparers) {
+		panic(fmt.Sprintf("assert: unknown ErrorMode %d", mode))
+	}
+	return errorComparers[mode]
+}
+
+// splitErrorComparer separates the error comparison options created by
+// CompareErrors from opts, returning the last one found and the remaining
+// options.
+func splitErrorComparer(opts []cmp.Option) (found cmp.Option, rest []cmp.Option) {
+	for _, opt := range opts {
+		if nested, ok := opt.(cmp.Options); ok {
+			f, r := splitErrorComparer(nested)
+			if f != nil {
+				found = f
+			}
+			rest = append(rest, cmp.Options(r))
+			continue
+		}
+		if isErrorComparer(opt) {
+			found = opt
+			continue
+		}
+		rest = append(rest, opt)
+	}
+	return found, rest
+}
+
+func isErrorComparer(opt cmp.Option) bool {
+	for _, c := range errorComparers {
+		if opt == c {
+			return true
+		}
 	}
-	return result
+	return false
 }
@@ -1,6 +1,8 @@
 package assert_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -18,6 +20,111 @@ func TestIgnore(t *testing.T) {
 	u1 := User{ID: 1, Name: "Alice", Created: time.Now()}
 	u2 := User{ID: 1, Name: "Bob", Created: time.Now().Add(5 * time.Minute)}
 
-	assert.False(t, cmp.Equal(u1, u2))
-	assert.True(t, cmp.Equal(u1, u2, assert.Ignore("Created", "Name")))
+	assert.NotEqual(t, u1, u2)
+	assert.Equal(t, u1, u2, assert.Ignore("Created", "Name"))
+}
+
+type codeError struct {
+	Code   int
+	detail string
+}
+
+func (e *codeError) Error() string { return "request failed" }
+
+func TestCompareErrors(t *testing.T) {
+	errNotFound := errors.New("not found")
+	wrapped := fmt.Errorf("load: %w", errNotFound)
+
+	t.Run("by message", func(t *testing.T) {
+		assert.Equal(t, errors.New("request failed"), &codeError{Code: 1})
+		assert.False(t, cmp.Equal(wrapped, errNotFound, assert.CompareErrors(assert.ErrorsByMessage)))
+	})
+
+	t.Run("by is", func(t *testing.T) {
+		assert.Equal(t, wrapped, errNotFound, assert.CompareErrors(assert.ErrorsByIs))
+		assert.Equal(t, errNotFound, wrapped, assert.CompareErrors(assert.ErrorsByIs))
+		assert.False(t, cmp.Equal(errors.New("not found"), errNotFound, assert.CompareErrors(assert.ErrorsByIs)))
+	})
+
+	t.Run("by type and message", func(t *testing.T) {
+		opt := assert.CompareErrors(assert.ErrorsByTypeAndMessage)
+		assert.Equal(t, &codeError{Code: 1}, &codeError{Code: 2}, opt)
+		assert.False(t, cmp.Equal(errors.New("request failed"), error(&codeError{Code: 1}), opt))
+	})
+
+	t.Run("by structure", func(t *testing.T) {
+		opt := assert.CompareErrors(assert.ErrorsByStructure)
+		assert.Equal(t, &codeError{Code: 1, detail: "x"}, &codeError{Code: 1, detail: "x"}, opt)
+		assert.False(t, cmp.Equal(&codeError{Code: 1}, &codeError{Code: 2}, opt))
+		assert.False(t, cmp.Equal(&codeError{Code: 1, detail: "x"}, &codeError{Code: 1, detail: "y"}, opt))
+	})
+
+	t.Run("in struct fields", func(t *testing.T) {
+		type result struct{ Err error }
+		got := result{Err: &codeError{Code: 1}}
+		want := result{Err: &codeError{Code: 2}}
+		assert.Equal(t, got, want)
+		assert.False(t, cmp.Equal(got, want, assert.CompareErrors(assert.ErrorsByStructure)))
+	})
+
+	t.Run("in nested options", func(t *testing.T) {
+		assert.Equal(t, wrapped, errNotFound, cmp.Options{assert.CompareErrors(assert.ErrorsByIs)})
+	})
+}
+
+type lineItem struct {
+	SKU       string
+	Price     int
+	CreatedAt time.Time
+}
+
+type order struct {
+	ID     int
+	Items  []lineItem
+	Labels map[string]string
+	Parent *order
+}
+
+func TestIgnorePatterns(t *testing.T) {
+	now := time.Now()
+	got := order{
+		ID:     1,
+		Items:  []lineItem{{SKU: "a", Price: 100, CreatedAt: now}},
+		Labels: map[string]string{"env": "prod", "team": "x"},
+		Parent: &order{ID: 2},
+	}
+	want := order{
+		ID:     1,
+		Items:  []lineItem{{SKU: "a", Price: 100, CreatedAt: now.Add(time.Hour)}},
+		Labels: map[string]string{"env": "test", "team": "x"},
+		Parent: &order{ID: 3},
+	}
+
+	assert.NotEqual(t, got, want, assert.Ignore("Items[*].CreatedAt", "Labels{env}"))
+	assert.Equal(t, got, want, assert.Ignore("Items[*].CreatedAt", "Labels{env}", "Parent.ID"))
+	assert.Equal(t, got, want, assert.Ignore("Items.CreatedAt", "Labels{*}", "Parent.*"))
+	assert.Equal(t, got, want, assert.Ignore("**.CreatedAt", "Labels", "Parent"))
+	assert.Equal(t, got, want, assert.Ignore("Items[0].CreatedAt", "Labels", "Parent"))
+}
+
+func TestOnly(t *testing.T) {
+	got := []order{{ID: 1, Items: []lineItem{{SKU: "a", Price: 100}}, Labels: map[string]string{"env": "prod"}}}
+	want := []order{{ID: 2, Items: []lineItem{{SKU: "b", Price: 100}}}}
+
+	assert.Equal(t, got, want, assert.Only("[*].Items[*].Price"))
+	assert.Equal(t, got, want, assert.Only("Items.Price"))
+	assert.NotEqual(t, got, want, assert.Only("Items"))
+	assert.NotEqual(t, got, want, assert.Only("Items.Price", "ID"))
+	assert.NotEqual(t, got, want, assert.Only("**.SKU"))
+}
+
+func TestCompareOnly(t *testing.T) {
+	got := order{ID: 1, Items: []lineItem{{SKU: "a", Price: 100}, {SKU: "b", Price: 200}}}
+	want := order{ID: 1, Items: []lineItem{{SKU: "a", Price: 150}, {SKU: "b", Price: 250}}}
+
+	assert.True(t, cmp.Equal(got, want, assert.CompareOnly(lineItem{}, "SKU")))
+	assert.False(t, cmp.Equal(got, want, assert.CompareOnly(lineItem{}, "Price")))
+	assert.False(t, cmp.Equal(order{ID: 1}, order{ID: 2}, assert.CompareOnly(lineItem{}, "SKU")))
+
+	assert.PanicsWithValue(t, func() { assert.CompareOnly(lineItem{}, "Sku") }, "assert: CompareOnly pattern \"Sku\" matches nothing in assert_test.lineItem; similar paths:\n  SKU")
 }
@@ -0,0 +1,155 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Greater asserts that got is greater than want. The values may be numbers of
+// any type, strings, time.Durations or time.Times; numbers of different types
+// are compared by value.
+func Greater(t testingT, got, want interface{}) bool {
+	t.Helper()
+	return assertOrder(t, "Greater", got, want, "greater than", func(c int) bool { return c > 0 })
+}
+
+// GreaterOrEqual asserts that got is greater than or equal to want. See
+// Greater.
+func GreaterOrEqual(t testingT, got, want interface{}) bool {
+	t.Helper()
+	return assertOrder(t, "GreaterOrEqual", got, want, "greater than or equal to", func(c int) bool { return c >= 0 })
+}
+
+// Less asserts that got is less than want. See Greater.
+func Less(t testingT, got, want interface{}) bool {
+	t.Helper()
+	return assertOrder(t, "Less", got, want, "less than", func(c int) bool { return c < 0 })
+}
+
+// LessOrEqual asserts that got is less than or equal to want. See Greater.
+func LessOrEqual(t testingT, got, want interface{}) bool {
+	t.Helper()
+	return assertOrder(t, "LessOrEqual", got, want, "less than or equal to", func(c int) bool { return c <= 0 })
+}
+
+// assertOrder asserts that the result of comparing got with want satisfies ok.
+func assertOrder(t testingT, fn string, got, want interface{}, relation string, ok func(c int) bool) bool {
+	t.Helper()
+	c, comparable := compareValues(got, want)
+	if !comparable {
+		msg := fmt.Sprintf("has unsupported type for %s: %T compared with %T", fn, got, want)
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	if !ok(c) {
+		msg := fmt.Sprintf("(%s) is not %s %s", fmtVal(got), relation, fmtVal(want))
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	return true
+}
+
+// Between asserts that got is between lo and hi, inclusive. The values may be
+// of the types accepted by Greater. (BetweenValues is the equivalent Matcher,
+// for use within want values.)
+func Between(t testingT, got, lo, hi interface{}) bool {
+	t.Helper()
+	c1, ok1 := compareValues(got, lo)
+	c2, ok2 := compareValues(got, hi)
+	if !ok1 || !ok2 {
+		msg := fmt.Sprintf("has unsupported type for Between: %T compared with %T and %T", got, lo, hi)
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	if c1 < 0 || c2 > 0 {
+		msg := fmt.Sprintf("(%s) is not between %s and %s", fmtVal(got), fmtVal(lo), fmtVal(hi))
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	return true
+}
+
+// Sorted asserts that the elements of got are in ascending order according to
+// less, which reports whether a must sort before b. Equal elements may be in
+// any order. If less is nil, the elements are compared as by Greater.
+func Sorted[T any](t testingT, got []T, less func(a, b T) bool) bool {
+	t.Helper()
+	for i := 1; i < len(got); i++ {
+		prev, v := got[i-1], got[i]
+		outOfOrder := false
+		if less != nil {
+			outOfOrder = less(v, prev)
+		} else {
+			c, ok := compareValues(v, prev)
+			if !ok {
+				msg := fmt.Sprintf("has unsupported element type for Sorted: %T", v)
+				t.Error(formatError(getArg(1)(), msg))
+				return false
+			}
+			outOfOrder = c < 0
+		}
+		if outOfOrder {
+			expr := getArg(1)()
+			msg := fmt.Sprintf("is not sorted: %s[%d] (%s) is less than %s[%d] (%s)",
+				expr, i, fmtVal(v), expr, i-1, fmtVal(prev))
+			t.Error(formatError(expr, msg))
+			return false
+		}
+	}
+	return true
+}
+
+// SortedBy asserts that the elements of the slice got, which must be structs or
+// pointers to structs, are in ascending order of the named field. The field may
+// be of any type accepted by Greater. For example:
+//
+//     assert.SortedBy(t, orders, "CreatedAt")
+func SortedBy(t testingT, got interface{}, field string) bool {
+	t.Helper()
+	v := reflect.ValueOf(got)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		msg := fmt.Sprintf("has unsupported type for SortedBy: %T", got)
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	fieldOf := func(i int) (interface{}, bool) {
+		e := v.Index(i)
+		for e.Kind() == reflect.Ptr || e.Kind() == reflect.Interface {
+			e = e.Elem()
+		}
+		if e.Kind() != reflect.Struct {
+			return nil, false
+		}
+		f := e.FieldByName(field)
+		if !f.IsValid() || !f.CanInterface() {
+			return nil, false
+		}
+		return f.Interface(), true
+	}
+	var prev interface{}
+	for i := 0; i < v.Len(); i++ {
+		cur, ok := fieldOf(i)
+		if !ok {
+			expr := fmt.Sprintf("%s[%d]", getArg(1)(), i)
+			t.Error(formatError(expr, "has no exported field "+field))
+			return false
+		}
+		if i > 0 {
+			c, ok := compareValues(cur, prev)
+			if !ok {
+				msg := fmt.Sprintf("has unsupported field type for SortedBy: %s is %T", field, cur)
+				t.Error(formatError(getArg(1)(), msg))
+				return false
+			}
+			if c < 0 {
+				expr := getArg(1)()
+				msg := fmt.Sprintf("is not sorted by %s: %s[%d].%s (%s) is less than %s[%d].%s (%s)",
+					field, expr, i, field, fmtVal(cur), expr, i-1, field, fmtVal(prev))
+				t.Error(formatError(expr, msg))
+				return false
+			}
+		}
+		prev = cur
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAssertGreater(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool { return Greater(mt, 2, 1) }, ``)
+	assert(t, func(mt *mockTestingT) bool { return Greater(mt, 2.5, int64(2)) }, ``)
+	assert(t, func(mt *mockTestingT) bool { return Greater(mt, "b", "a") }, ``)
+	assert(t, func(mt *mockTestingT) bool { return Greater(mt, 2*time.Second, time.Second) }, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		count := 1
+		return Greater(mt, count, 1)
+	}, `count (1) is not greater than 1`)
+	assert(t, func(mt *mockTestingT) bool {
+		name := "a"
+		return Greater(mt, name, "b")
+	}, `name ("a") is not greater than "b"`)
+	assert(t, func(mt *mockTestingT) bool {
+		name := "a"
+		return Greater(mt, name, 1)
+	}, `name has unsupported type for Greater: string compared with int`)
+}
+
+func TestAssertGreaterOrEqual(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool { return GreaterOrEqual(mt, 1, 1) }, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		elapsed := 500 * time.Millisecond
+		return GreaterOrEqual(mt, elapsed, time.Second)
+	}, `elapsed (500ms) is not greater than or equal to 1s`)
+}
+
+func TestAssertLess(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	assert(t, func(mt *mockTestingT) bool { return Less(mt, now, now.Add(time.Second)) }, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		return Less(mt, now, now)
+	}, `now (2024-01-01 10:00:00 +0000 UTC) is not less than 2024-01-01 10:00:00 +0000 UTC`)
+}
+
+func TestAssertLessOrEqual(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool { return LessOrEqual(mt, uint8(3), 3.0) }, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		total := 3.5
+		return LessOrEqual(mt, total, 3)
+	}, `total (3.5) is not less than or equal to 3`)
+}
+
+func TestBetween(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool { return Between(mt, 5, 1, 10) }, ``)
+	assert(t, func(mt *mockTestingT) bool { return Between(mt, 10, 1, 10) }, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		eta := 45 * time.Minute
+		return Between(mt, eta, 10*time.Minute, 30*time.Minute)
+	}, `eta (45m0s) is not between 10m0s and 30m0s`)
+	assert(t, func(mt *mockTestingT) bool {
+		return Between(mt, 5, "a", 10)
+	}, `5 has unsupported type for Between: int compared with string and int`)
+}
+
+func TestOrderLargeIntegers(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	assert(t, func(mt *mockTestingT) bool { return Greater(mt, now.Add(100).UnixNano(), now.UnixNano()) }, ``)
+	assert(t, func(mt *mockTestingT) bool { return Greater(mt, int64(1<<62+1), 1<<62) }, ``)
+	assert(t, func(mt *mockTestingT) bool { return Less(mt, time.Duration(1<<60), time.Duration(1<<60+1)) }, ``)
+	assert(t, func(mt *mockTestingT) bool { return Greater(mt, uint64(1<<63+1), uint64(1<<63)) }, ``)
+	assert(t, func(mt *mockTestingT) bool { return Greater(mt, uint64(1<<63), int64(1<<63-1)) }, ``)
+	assert(t, func(mt *mockTestingT) bool { return Less(mt, -1, uint64(1<<63)) }, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		return Between(mt, now.Add(1).UnixNano(), now.UnixNano(), now.Add(2).UnixNano())
+	}, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		start := now.UnixNano()
+		return GreaterOrEqual(mt, start, now.Add(1).UnixNano())
+	}, `start (1704103200000000000) is not greater than or equal to 1704103200000000001`)
+	assert(t, func(mt *mockTestingT) bool {
+		timeouts := []time.Duration{1<<60 + 1, 1 << 60}
+		return Sorted(mt, timeouts, nil)
+	}, `timeouts is not sorted: timeouts[1] (`)
+	assert(t, func(mt *mockTestingT) bool {
+		type event struct{ At int64 }
+		events := []event{{now.Add(1).UnixNano()}, {now.UnixNano()}}
+		return SortedBy(mt, events, "At")
+	}, `events is not sorted by At: events[1].At (1704103200000000000) is less than events[0].At (1704103200000000001)`)
+}
+
+func TestAssertSorted(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool { return Sorted(mt, []int{1, 2, 2, 3}, nil) }, ``)
+	assert(t, func(mt *mockTestingT) bool { return Sorted(mt, []string(nil), nil) }, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		ids := []int{1, 7, 2}
+		return Sorted(mt, ids, nil)
+	}, `ids is not sorted: ids[2] (2) is less than ids[1] (7)`)
+	assert(t, func(mt *mockTestingT) bool {
+		names := []string{"b", "A", "c"}
+		return Sorted(mt, names, func(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) })
+	}, `names is not sorted: names[1] ("A") is less than names[0] ("b")`)
+	assert(t, func(mt *mockTestingT) bool {
+		return Sorted(mt, []interface{}{1, "a"}, nil)
+	}, `[]interface{}{1, "a"} has unsupported element type for Sorted: string`)
+}
+
+func TestAssertSortedBy(t *testing.T) {
+	type order struct {
+		ID        int
+		CreatedAt time.Time
+		items     []string
+	}
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	orders := []*order{{ID: 1, CreatedAt: now}, {ID: 2, CreatedAt: now.Add(time.Hour)}, {ID: 3, CreatedAt: now}}
+
+	assert(t, func(mt *mockTestingT) bool { return SortedBy(mt, orders, "ID") }, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		return SortedBy(mt, orders, "CreatedAt")
+	}, `orders is not sorted by CreatedAt: orders[2].CreatedAt (2024-01-01 10:00:00 +0000 UTC) is less than orders[1].CreatedAt (2024-01-01 11:00:00 +0000 UTC)`)
+	assert(t, func(mt *mockTestingT) bool {
+		return SortedBy(mt, orders, "items")
+	}, `orders[0] has no exported field items`)
+	assert(t, func(mt *mockTestingT) bool {
+		return SortedBy(mt, 1, "ID")
+	}, `1 has unsupported type for SortedBy: int`)
+}
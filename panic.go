@@ -0,0 +1,91 @@
+package assert
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Panics asserts that fn panics.
+func Panics(t testingT, fn func()) bool {
+	t.Helper()
+	if panicked, _, _ := didPanic(fn); !panicked {
+		t.Error(formatError(getArg(1)(), "did not panic"))
+		return false
+	}
+	return true
+}
+
+// NotPanics asserts that fn does not panic.
+func NotPanics(t testingT, fn func()) bool {
+	t.Helper()
+	if panicked, value, stack := didPanic(fn); panicked {
+		msg := fmt.Sprintf("panicked: %v\n%s", value, stack)
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	return true
+}
+
+// PanicsWithValue asserts that fn panics with a value equal to want.
+func PanicsWithValue(t testingT, fn func(), want interface{}, opts ...cmp.Option) bool {
+	t.Helper()
+	t, opts = annotate(t, opts)
+	panicked, value, stack := didPanic(fn)
+	if !panicked {
+		t.Error(formatError(getArg(1)(), "did not panic"))
+		return false
+	}
+	opts = withDefaults(t, opts)
+	diff, err := diffValues(value, want, opts)
+	if err != nil {
+		t.Error("diff error:", err)
+		return false
+	}
+	if diff != "" {
+		msg := formatDiff(getArg(1)(), "panicked with (-got +want): ", diff)
+		t.Error(fmt.Sprintf("%s\n%s", msg, stack))
+		return false
+	}
+	return true
+}
+
+// PanicsWithError asserts that fn panics with an error whose message contains
+// the wanted string.
+func PanicsWithError(t testingT, fn func(), want string) bool {
+	t.Helper()
+	panicked, value, stack := didPanic(fn)
+	if !panicked {
+		t.Error(formatError(getArg(1)(), "did not panic"))
+		return false
+	}
+	err, ok := value.(error)
+	if !ok {
+		msg := fmt.Sprintf("panicked with non-error value: %v\n%s", value, stack)
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	if !strings.Contains(err.Error(), want) {
+		msg := fmt.Sprintf("panicked with (%q) which does not contain %q\n%s", err.Error(), want, stack)
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	}
+	return true
+}
+
+// didPanic calls fn, and returns whether it panicked, the value it panicked
+// with and the stack trace at the point of the panic.
+func didPanic(fn func()) (panicked bool, value interface{}, stack []byte) {
+	panicked = true
+	defer func() {
+		if panicked {
+			value = recover()
+			stack = debug.Stack()
+		}
+	}()
+	fn()
+	panicked = false
+	return
+}
@@ -0,0 +1,85 @@
+package assert
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPanics(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		explode := func() { panic("boom") }
+		return Panics(mt, explode)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			noop := func() {}
+			return Panics(mt, noop)
+		},
+		`noop did not panic`)
+}
+
+func TestNotPanics(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		noop := func() {}
+		return NotPanics(mt, noop)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			explode := func() { panic("boom") }
+			return NotPanics(mt, explode)
+		},
+		"explode panicked: boom\ngoroutine ")
+}
+
+func TestPanicsWithValue(t *testing.T) {
+	type reason struct{ Code int }
+
+	assert(t, func(mt *mockTestingT) bool {
+		explode := func() { panic(reason{Code: 1}) }
+		return PanicsWithValue(mt, explode, reason{Code: 1})
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			explode := func() { panic(reason{Code: 1}) }
+			return PanicsWithValue(mt, explode, reason{Code: 2})
+		},
+		`explode panicked with (-got +want):`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			noop := func() {}
+			return PanicsWithValue(mt, noop, "boom")
+		},
+		`noop did not panic`)
+}
+
+func TestPanicsWithError(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		explode := func() { panic(errors.New("invalid order")) }
+		return PanicsWithError(mt, explode, "invalid")
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			explode := func() { panic(errors.New("invalid order")) }
+			return PanicsWithError(mt, explode, "missing")
+		},
+		`explode panicked with ("invalid order") which does not contain "missing"`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			explode := func() { panic("boom") }
+			return PanicsWithError(mt, explode, "boom")
+		},
+		`explode panicked with non-error value: boom`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			noop := func() {}
+			return PanicsWithError(mt, noop, "boom")
+		},
+		`noop did not panic`)
+}
@@ -0,0 +1,546 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// A pathPattern matches locations within a Go value, as given to Ignore, Only
+// and CompareOnly. See Ignore for the syntax.
+type pathPattern struct {
+	src    string
+	tokens []pathToken
+}
+
+type pathTokenKind int
+
+const (
+	fieldToken    pathTokenKind = iota // Name or *
+	anyDepthToken                      // **
+	indexToken                         // [n] or [*]
+	keyToken                           // {key} or {*}
+)
+
+type pathToken struct {
+	kind pathTokenKind
+	any  bool
+	name string // field name or map key
+	n    int    // index
+}
+
+// parsePathPattern parses a path pattern.
+func parsePathPattern(s string) (*pathPattern, error) {
+	p := &pathPattern{src: s}
+	rest := s
+	for rest != "" {
+		if len(p.tokens) > 0 {
+			switch rest[0] {
+			case '.':
+				rest = rest[1:]
+				if rest == "" || rest[0] == '[' || rest[0] == '{' {
+					return nil, fmt.Errorf("invalid path pattern %q: expected field name after '.'", s)
+				}
+			case '[', '{':
+			default:
+				return nil, fmt.Errorf("invalid path pattern %q: unexpected %q", s, rest[0])
+			}
+		}
+		var tok pathToken
+		switch {
+		case strings.HasPrefix(rest, "**"):
+			tok = pathToken{kind: anyDepthToken}
+			rest = rest[2:]
+		case rest[0] == '*':
+			tok = pathToken{kind: fieldToken, any: true}
+			rest = rest[1:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path pattern %q: missing ']'", s)
+			}
+			tok = pathToken{kind: indexToken}
+			if index := rest[1:end]; index == "*" {
+				tok.any = true
+			} else if n, err := strconv.Atoi(index); err == nil && n >= 0 {
+				tok.n = n
+			} else {
+				return nil, fmt.Errorf("invalid path pattern %q: invalid index %q", s, index)
+			}
+			rest = rest[end+1:]
+		case rest[0] == '{':
+			end := strings.IndexByte(rest, '}')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path pattern %q: missing '}'", s)
+			}
+			tok = pathToken{kind: keyToken, name: rest[1:end], any: rest[1:end] == "*"}
+			rest = rest[end+1:]
+		default:
+			end := strings.IndexAny(rest, ".[{*")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path pattern %q: unexpected %q", s, rest[0])
+			}
+			tok = pathToken{kind: fieldToken, name: rest[:end]}
+			rest = rest[end:]
+		}
+		p.tokens = append(p.tokens, tok)
+	}
+	return p, nil
+}
+
+// mustParsePathPatterns parses the patterns given to the option fn, panicking
+// if any are invalid.
+func mustParsePathPatterns(fn string, patterns []string) []*pathPattern {
+	result := make([]*pathPattern, len(patterns))
+	for i, s := range patterns {
+		p, err := parsePathPattern(s)
+		if err != nil {
+			panic(fmt.Sprintf("assert: %s: %v", fn, err))
+		}
+		result[i] = p
+	}
+	return result
+}
+
+// pathStep is a step in a cmp path, as seen by a pathPattern.
+type pathStep struct {
+	kind   pathTokenKind // fieldToken, indexToken or keyToken
+	name   string        // field name or formatted map key
+	ix, iy int           // index in each value
+}
+
+// pathSteps converts a cmp path into the steps matched by patterns. Pointer
+// indirections, type assertions and transformations are omitted.
+func pathSteps(p cmp.Path) []pathStep {
+	var steps []pathStep
+	for _, ps := range p {
+		switch s := ps.(type) {
+		case cmp.StructField:
+			steps = append(steps, pathStep{kind: fieldToken, name: s.Name()})
+		case cmp.SliceIndex:
+			ix, iy := s.SplitKeys()
+			steps = append(steps, pathStep{kind: indexToken, ix: ix, iy: iy})
+		case cmp.MapIndex:
+			steps = append(steps, pathStep{kind: keyToken, name: formatKey(s.Key())})
+		}
+	}
+	return steps
+}
+
+func formatKey(k reflect.Value) string {
+	if k.CanInterface() {
+		return fmt.Sprint(k.Interface())
+	}
+	return fmt.Sprint(k)
+}
+
+// matches reports whether the pattern matches the location reached by steps.
+func (p *pathPattern) matches(steps []pathStep) bool {
+	return matchTokens(p.tokens, steps, false)
+}
+
+// matchesAncestor reports whether the pattern matches the location reached by
+// steps or any location containing it.
+func (p *pathPattern) matchesAncestor(steps []pathStep) bool {
+	for i := len(steps); i >= 0; i-- {
+		if p.matches(steps[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesDescendant reports whether the pattern might match a location within
+// the one reached by steps.
+func (p *pathPattern) matchesDescendant(steps []pathStep) bool {
+	return matchTokens(p.tokens, steps, true)
+}
+
+// matchTokens matches tokens against steps. If partial is true, it reports
+// whether steps could be extended to match.
+func matchTokens(tokens []pathToken, steps []pathStep, partial bool) bool {
+	if len(tokens) == 0 {
+		return len(steps) == 0
+	}
+	if len(steps) == 0 && partial {
+		return true
+	}
+	tok := tokens[0]
+	switch tok.kind {
+	case anyDepthToken:
+		if partial {
+			return true
+		}
+		return matchTokens(tokens[1:], steps, false) ||
+			len(steps) > 0 && matchTokens(tokens, steps[1:], false)
+	case fieldToken:
+		// Elements and entries not mentioned by the pattern are passed through.
+		for len(steps) > 0 && steps[0].kind != fieldToken {
+			steps = steps[1:]
+		}
+		if len(steps) == 0 {
+			return partial
+		}
+		if !tok.any && steps[0].name != tok.name {
+			return false
+		}
+	case indexToken:
+		if len(steps) == 0 || steps[0].kind != indexToken {
+			return false
+		}
+		if !tok.any && steps[0].ix != tok.n && steps[0].iy != tok.n {
+			return false
+		}
+	case keyToken:
+		if len(steps) == 0 || steps[0].kind != keyToken {
+			return false
+		}
+		if !tok.any && steps[0].name != tok.name {
+			return false
+		}
+	}
+	return matchTokens(tokens[1:], steps[1:], partial)
+}
+
+// possibleIn reports whether the pattern could match any location within a
+// value of type t. Locations within interfaces can't be known, so they are
+// assumed to match.
+func (p *pathPattern) possibleIn(t reflect.Type) bool {
+	type state struct {
+		tokens int
+		t      reflect.Type
+	}
+	seen := map[state]bool{}
+	var possible func(tokens []pathToken, t reflect.Type) bool
+	possible = func(tokens []pathToken, t reflect.Type) bool {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if len(tokens) == 0 || t.Kind() == reflect.Interface {
+			return true
+		}
+		s := state{len(tokens), t}
+		if seen[s] {
+			// A recursive type: the state is already being explored.
+			return false
+		}
+		seen[s] = true
+
+		switch tok := tokens[0]; tok.kind {
+		case anyDepthToken:
+			if possible(tokens[1:], t) {
+				return true
+			}
+			for _, c := range childTypes(t) {
+				if possible(tokens, c) {
+					return true
+				}
+			}
+		case fieldToken:
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				return possible(tokens, t.Elem())
+			case reflect.Struct:
+				for i := 0; i < t.NumField(); i++ {
+					f := t.Field(i)
+					if (tok.any || f.Name == tok.name) && possible(tokens[1:], f.Type) {
+						return true
+					}
+				}
+			}
+		case indexToken:
+			if k := t.Kind(); k == reflect.Slice || k == reflect.Array {
+				return possible(tokens[1:], t.Elem())
+			}
+		case keyToken:
+			if t.Kind() == reflect.Map {
+				return possible(tokens[1:], t.Elem())
+			}
+		}
+		return false
+	}
+	return possible(p.tokens, t)
+}
+
+// childTypes returns the types of the values directly within a value of type
+// t.
+func childTypes(t reflect.Type) []reflect.Type {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return []reflect.Type{t.Elem()}
+	case reflect.Struct:
+		types := make([]reflect.Type, t.NumField())
+		for i := range types {
+			types[i] = t.Field(i).Type
+		}
+		return types
+	}
+	return nil
+}
+
+// pathOption is an option created by Ignore or Only. It records the patterns
+// of the filter it wraps, so that they can be checked against the values being
+// compared, and is replaced by the filter (see unwrapOptions) before the
+// options reach cmp, which doesn't accept it.
+type pathOption struct {
+	cmp.Option
+	fn       string
+	patterns []*pathPattern
+}
+
+// checkPathPatterns returns an error if any pattern given to Ignore or Only in
+// opts (the options passed directly to an assertion) can't match anything in
+// a value of the same type as v. Otherwise, it returns a recorder to be passed to the comparison as a cmp.Reporter, which
+// checks that the patterns selecting particular elements and entries did match
+// something, or nil if there are none.
+func checkPathPatterns(v interface{}, opts []cmp.Option) (*pathRecorder, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, nil
+	}
+	var rec *pathRecorder
+	for _, o := range findPathOptions(opts) {
+		for _, p := range o.patterns {
+			if !p.possibleIn(t) {
+				return nil, unmatchedPatternError(o.fn, p, t.String(), typePaths(t, len(p.tokens)+1))
+			}
+			if p.isSpecific() {
+				if rec == nil {
+					rec = &pathRecorder{}
+				}
+				rec.checks = append(rec.checks, pathCheck{fn: o.fn, pattern: p, shape: p.shape()})
+			}
+		}
+	}
+	return rec, nil
+}
+
+// findPathOptions returns the options in opts created by Ignore and Only.
+func findPathOptions(opts []cmp.Option) []pathOption {
+	var result []pathOption
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case cmp.Options:
+			result = append(result, findPathOptions(opt)...)
+		case pathOption:
+			result = append(result, opt)
+		}
+	}
+	return result
+}
+
+// isSpecific reports whether the pattern selects a particular element or
+// entry, which can only be checked against the values being compared.
+func (p *pathPattern) isSpecific() bool {
+	for _, tok := range p.tokens {
+		if (tok.kind == indexToken || tok.kind == keyToken) && !tok.any {
+			return true
+		}
+	}
+	return false
+}
+
+// shape returns a copy of the pattern matching any element or entry in place
+// of particular ones.
+func (p *pathPattern) shape() *pathPattern {
+	s := &pathPattern{src: p.src, tokens: make([]pathToken, len(p.tokens))}
+	copy(s.tokens, p.tokens)
+	for i, tok := range s.tokens {
+		if tok.kind == indexToken || tok.kind == keyToken {
+			s.tokens[i].any = true
+		}
+	}
+	return s
+}
+
+// pathRecorder is a cmp.Reporter recording the locations visited during a
+// comparison, to check that patterns matched some of them.
+type pathRecorder struct {
+	checks  []pathCheck
+	path    cmp.Path
+	visited [][]pathStep
+}
+
+// pathCheck is a pattern to be checked by a pathRecorder. If the pattern
+// doesn't match any location visited but its shape does, the pattern most
+// likely names an element or entry which doesn't exist.
+type pathCheck struct {
+	fn             string
+	pattern, shape *pathPattern
+}
+
+func (r *pathRecorder) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+	r.visited = append(r.visited, pathSteps(r.path))
+}
+
+func (r *pathRecorder) Report(cmp.Result) {}
+
+func (r *pathRecorder) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+// check returns an error if a pattern selecting particular elements or entries
+// matched none of the locations visited, although locations of the same shape
+// were visited.
+func (r *pathRecorder) check() error {
+	if r == nil {
+		return nil
+	}
+checks:
+	for _, c := range r.checks {
+		var similar []string
+		for _, steps := range r.visited {
+			if c.pattern.matches(steps) {
+				continue checks
+			}
+			if c.shape.matches(steps) {
+				similar = append(similar, formatPathSteps(steps))
+			}
+		}
+		if len(similar) > 0 {
+			return unmatchedPatternError(c.fn, c.pattern, "the values compared", similar)
+		}
+	}
+	return nil
+}
+
+// formatPathSteps formats steps in the syntax of a path pattern.
+func formatPathSteps(steps []pathStep) string {
+	var b strings.Builder
+	for _, step := range steps {
+		switch step.kind {
+		case fieldToken:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(step.name)
+		case indexToken:
+			i := step.ix
+			if i < 0 {
+				i = step.iy
+			}
+			fmt.Fprintf(&b, "[%d]", i)
+		case keyToken:
+			b.WriteString("{" + step.name + "}")
+		}
+	}
+	return b.String()
+}
+
+// typePaths returns the paths, in the syntax of a path pattern, of the
+// locations within a value of type t up to depth steps deep.
+func typePaths(t reflect.Type, depth int) []string {
+	var result []string
+	var walk func(t reflect.Type, prefix string, depth int, parents map[reflect.Type]bool)
+	walk = func(t reflect.Type, prefix string, depth int, parents map[reflect.Type]bool) {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if depth == 0 || parents[t] {
+			return
+		}
+		parents[t] = true
+		defer delete(parents, t)
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array:
+			result = append(result, prefix+"[*]")
+			walk(t.Elem(), prefix+"[*]", depth-1, parents)
+		case reflect.Map:
+			result = append(result, prefix+"{*}")
+			walk(t.Elem(), prefix+"{*}", depth-1, parents)
+		case reflect.Struct:
+			for i := 0; i < t.NumField(); i++ {
+				p := t.Field(i).Name
+				if prefix != "" {
+					p = prefix + "." + p
+				}
+				result = append(result, p)
+				walk(t.Field(i).Type, p, depth-1, parents)
+			}
+		}
+	}
+	walk(t, "", depth, map[reflect.Type]bool{})
+	return result
+}
+
+// maxCandidates is the maximum number of similar paths suggested when a
+// pattern matches nothing.
+const maxCandidates = 5
+
+// unmatchedPatternError returns an error reporting that the pattern given to
+// the option fn matched nothing in what, listing the paths among candidates
+// most similar to the pattern.
+func unmatchedPatternError(fn string, p *pathPattern, what string, candidates []string) error {
+	msg := fmt.Sprintf("%s pattern %q matches nothing in %s", fn, p.src, what)
+	if similar := similarPaths(p.src, candidates); len(similar) > 0 {
+		msg += "; similar paths:\n  " + strings.Join(similar, "\n  ")
+	}
+	return errors.New(msg)
+}
+
+// similarPaths returns the candidates closest to s by edit distance, omitting
+// those too different to be plausible.
+func similarPaths(s string, candidates []string) []string {
+	type candidate struct {
+		path     string
+		distance int
+	}
+	limit := len(s)/3 + 2
+	var close []candidate
+	seen := map[string]bool{}
+	for _, c := range candidates {
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+		if d := editDistance(s, c); d <= limit {
+			close = append(close, candidate{c, d})
+		}
+	}
+	sort.SliceStable(close, func(i, j int) bool { return close[i].distance < close[j].distance })
+	var result []string
+	for i := 0; i < len(close) && i < maxCandidates; i++ {
+		result = append(result, close[i].path)
+	}
+	return result
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,129 @@
+package assert
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParsePathPattern(t *testing.T) {
+	for _, s := range []string{"", "ID", "User.ID", "Items[*].CreatedAt", "[0].Labels{env}", "**.UpdatedAt", "Meta.*", "M{*}[3]"} {
+		if _, err := parsePathPattern(s); err != nil {
+			t.Errorf("parsePathPattern(%q): %v", s, err)
+		}
+	}
+	for s, want := range map[string]string{
+		".ID":       `invalid path pattern ".ID": unexpected '.'`,
+		"User.":     `invalid path pattern "User.": expected field name after '.'`,
+		"Items.[0]": `invalid path pattern "Items.[0]": expected field name after '.'`,
+		"Items[x]":  `invalid path pattern "Items[x]": invalid index "x"`,
+		"Items[*":   `invalid path pattern "Items[*": missing ']'`,
+		"Labels{a":  `invalid path pattern "Labels{a": missing '}'`,
+		"A*":        `invalid path pattern "A*": unexpected '*'`,
+	} {
+		_, err := parsePathPattern(s)
+		if err == nil || err.Error() != want {
+			t.Errorf("parsePathPattern(%q) = %v, want %s", s, err, want)
+		}
+	}
+}
+
+func TestPathPatternPossibleIn(t *testing.T) {
+	type node struct {
+		Name     string
+		Children []*node
+		Meta     map[string]interface{}
+	}
+	typ := reflect.TypeOf(&node{})
+	for s, want := range map[string]bool{
+		"Name":                   true,
+		"Nmae":                   false,
+		"Children[*].Name":       true,
+		"Children.Children.Name": true,
+		"Children{a}":            false,
+		"Meta{a}.Anything":       true,
+		"**.Name":                true,
+		"Children.Created":       false,
+		"*.Name":                 true,
+		"Name.Length":            false,
+	} {
+		p, err := parsePathPattern(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := p.possibleIn(typ); got != want {
+			t.Errorf("%q possibleIn %v = %v, want %v", s, typ, got, want)
+		}
+	}
+}
+
+func TestIgnoreMatchesNothing(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	type item struct {
+		CreatedAt time.Time
+	}
+	type order struct {
+		User   user
+		Items  []item
+		Labels map[string]string
+	}
+	got := order{Items: []item{{}}, Labels: map[string]string{"env": "prod", "team": "x"}}
+
+	assert(t, func(mt *mockTestingT) bool {
+		return Equal(mt, order{}, order{}, Ignore("Items[*].CreatdAt"))
+	}, removeLeadingTabs(`
+		invalid option: Ignore pattern "Items[*].CreatdAt" matches nothing in assert.order; similar paths:
+		  Items[*].CreatedAt`)[1:])
+	assert(t, func(mt *mockTestingT) bool {
+		return NotEqual(mt, order{}, order{Items: []item{{}}}, Only("Usr.ID"))
+	}, removeLeadingTabs(`
+		invalid option: Only pattern "Usr.ID" matches nothing in assert.order; similar paths:
+		  User.ID
+		  User`)[1:])
+	assert(t, func(mt *mockTestingT) bool {
+		return Equal(mt, got, got, Ignore("Labels{evn}"))
+	}, removeLeadingTabs(`
+		invalid option: Ignore pattern "Labels{evn}" matches nothing in the values compared; similar paths:
+		  Labels{env}`)[1:])
+	assert(t, func(mt *mockTestingT) bool {
+		return Equal(mt, got, got, Ignore("Items[1]"))
+	}, removeLeadingTabs(`
+		invalid option: Ignore pattern "Items[1]" matches nothing in the values compared; similar paths:
+		  Items[0]`)[1:])
+	assert(t, func(mt *mockTestingT) bool {
+		return Equal(mt, got, got, Ignore("Xyz"))
+	}, `invalid option: Ignore pattern "Xyz" matches nothing in assert.order`)
+
+	// Patterns selecting elements or entries of empty slices and maps aren't
+	// reported, as there's nothing to compare them against.
+	Equal(t, order{}, order{}, Ignore("Labels{env}", "Items[0].CreatedAt"))
+	Equal(t, got, got, Ignore("Labels{env}", "Items[0].CreatedAt"))
+
+	// Patterns in scoped and registered options may be intended for other
+	// types, so aren't checked.
+	assert(t, func(mt *mockTestingT) bool {
+		return Equal(WithOptions(mt, Ignore("CreatedAt")), 1, 1)
+	}, ``)
+	assert(t, func(mt *mockTestingT) bool {
+		return New(mt, Ignore("User.ID")).Equal("a", "a")
+	}, ``)
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"ID", "", 2},
+		{"User.ID", "Usr.ID", 1},
+		{"kitten", "sitting", 3},
+	} {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
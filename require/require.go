@@ -0,0 +1,296 @@
+// Package require provides the same assertions as package assert, except that
+// they stop the test with t.Fatal when they fail rather than reporting the
+// failure with t.Error and continuing.
+//
+// Use it when the rest of the test depends on the assertion holding, for
+// example before dereferencing a result that must not be nil:
+//
+//     require.NotNil(t, user)
+//     assert.Equal(t, user.Name, "Alice")
+package require
+
+import (
+	"time"
+
+	"github.com/deliveroo/assert-go"
+	"github.com/google/go-cmp/cmp"
+)
+
+// testingT is a simplified interface of the testing.T.
+type testingT interface {
+	Helper()
+	Error(args ...interface{})
+	Fatal(args ...interface{})
+}
+
+// Equal asserts that got and want are equal.
+func Equal(t testingT, got, want interface{}, opts ...cmp.Option) {
+	t.Helper()
+	assert.Equal(fatal(t), got, want, opts...)
+}
+
+// NotEqual asserts that got and want are not equal.
+func NotEqual(t testingT, got, want interface{}, opts ...cmp.Option) {
+	t.Helper()
+	assert.NotEqual(fatal(t), got, want, opts...)
+}
+
+// ErrorContains asserts that the error message contains the wanted string.
+func ErrorContains(t testingT, got error, want string) {
+	t.Helper()
+	assert.ErrorContains(fatal(t), got, want)
+}
+
+// ErrorIs asserts that err matches target, as reported by errors.Is.
+func ErrorIs(t testingT, err, target error) {
+	t.Helper()
+	assert.ErrorIs(fatal(t), err, target)
+}
+
+// NotErrorIs asserts that err does not match target, as reported by errors.Is.
+func NotErrorIs(t testingT, err, target error) {
+	t.Helper()
+	assert.NotErrorIs(fatal(t), err, target)
+}
+
+// ErrorAs asserts that err has an error in its chain that can be assigned to
+// target, as reported by errors.As.
+func ErrorAs(t testingT, err error, target interface{}) {
+	t.Helper()
+	assert.ErrorAs(fatal(t), err, target)
+}
+
+// ErrorChain asserts that every one of targets is found in the chain of err.
+func ErrorChain(t testingT, err error, targets ...error) {
+	t.Helper()
+	assert.ErrorChain(fatal(t), err, targets...)
+}
+
+// JSONEqual asserts that got and want are equal when represented as JSON.
+func JSONEqual(t testingT, got, want interface{}, opts ...cmp.Option) {
+	t.Helper()
+	assert.JSONEqual(fatal(t), got, want, opts...)
+}
+
+// JSONContains asserts that want is a subset of got when both are represented
+// as JSON.
+func JSONContains(t testingT, got, want interface{}, opts ...cmp.Option) {
+	t.Helper()
+	assert.JSONContains(fatal(t), got, want, opts...)
+}
+
+// JSONSchema asserts that the JSON representation of subject is valid
+// according to a JSON Schema.
+func JSONSchema(t testingT, subject, schema interface{}) {
+	t.Helper()
+	assert.JSONSchema(fatal(t), subject, schema)
+}
+
+// YAMLEqual asserts that got and want are equal when represented as YAML.
+func YAMLEqual(t testingT, got, want interface{}, opts ...cmp.Option) {
+	t.Helper()
+	assert.YAMLEqual(fatal(t), got, want, opts...)
+}
+
+// TOMLEqual asserts that got and want are equal when represented as TOML.
+func TOMLEqual(t testingT, got, want interface{}, opts ...cmp.Option) {
+	t.Helper()
+	assert.TOMLEqual(fatal(t), got, want, opts...)
+}
+
+// JSONPath asserts that evaluating the path expression against the subject
+// results in want.
+func JSONPath(t testingT, subject interface{}, path string, want interface{}, opts ...cmp.Option) {
+	t.Helper()
+	assert.JSONPath(fatal(t), subject, path, want, opts...)
+}
+
+// JSONPathExists asserts that evaluating the path expression against the
+// subject selects a value.
+func JSONPathExists(t testingT, subject interface{}, path string) {
+	t.Helper()
+	assert.JSONPathExists(fatal(t), subject, path)
+}
+
+// JSONPathNotExists asserts that evaluating the path expression against the
+// subject selects nothing.
+func JSONPathNotExists(t testingT, subject interface{}, path string) {
+	t.Helper()
+	assert.JSONPathNotExists(fatal(t), subject, path)
+}
+
+// JSONPathMatches asserts that evaluating the path expression against the
+// subject results in a value for which match returns true.
+func JSONPathMatches(t testingT, subject interface{}, path string, match func(v interface{}) bool) {
+	t.Helper()
+	assert.JSONPathMatches(fatal(t), subject, path, match)
+}
+
+// Contains asserts that got contains want.
+func Contains(t testingT, got, want interface{}, opts ...cmp.Option) {
+	t.Helper()
+	assert.Contains(fatal(t), got, want, opts...)
+}
+
+// ContainsAll asserts that got contains all items of want.
+func ContainsAll(t testingT, got, want interface{}, opts ...cmp.Option) {
+	t.Helper()
+	assert.ContainsAll(fatal(t), got, want, opts...)
+}
+
+// True asserts that got is true.
+func True(t testingT, got bool) {
+	t.Helper()
+	assert.True(fatal(t), got)
+}
+
+// False asserts that got is false.
+func False(t testingT, got bool) {
+	t.Helper()
+	assert.False(fatal(t), got)
+}
+
+// Match asserts that got matches the regex want.
+func Match(t testingT, got, want string) {
+	t.Helper()
+	assert.Match(fatal(t), got, want)
+}
+
+// Greater asserts that got is greater than want.
+func Greater(t testingT, got, want interface{}) {
+	t.Helper()
+	assert.Greater(fatal(t), got, want)
+}
+
+// GreaterOrEqual asserts that got is greater than or equal to want.
+func GreaterOrEqual(t testingT, got, want interface{}) {
+	t.Helper()
+	assert.GreaterOrEqual(fatal(t), got, want)
+}
+
+// Less asserts that got is less than want.
+func Less(t testingT, got, want interface{}) {
+	t.Helper()
+	assert.Less(fatal(t), got, want)
+}
+
+// LessOrEqual asserts that got is less than or equal to want.
+func LessOrEqual(t testingT, got, want interface{}) {
+	t.Helper()
+	assert.LessOrEqual(fatal(t), got, want)
+}
+
+// Between asserts that got is between lo and hi, inclusive.
+func Between(t testingT, got, lo, hi interface{}) {
+	t.Helper()
+	assert.Between(fatal(t), got, lo, hi)
+}
+
+// Sorted asserts that the elements of got are in ascending order according to
+// less, or as compared by Greater if less is nil.
+func Sorted[T any](t testingT, got []T, less func(a, b T) bool) {
+	t.Helper()
+	assert.Sorted(fatal(t), got, less)
+}
+
+// SortedBy asserts that the elements of the slice got are in ascending order
+// of the named field.
+func SortedBy(t testingT, got interface{}, field string) {
+	t.Helper()
+	assert.SortedBy(fatal(t), got, field)
+}
+
+// InDelta asserts that the numbers got and want differ by no more than delta.
+func InDelta(t testingT, got, want interface{}, delta float64) {
+	t.Helper()
+	assert.InDelta(fatal(t), got, want, delta)
+}
+
+// InEpsilon asserts that the relative error between the numbers got and want
+// is no more than epsilon.
+func InEpsilon(t testingT, got, want interface{}, epsilon float64) {
+	t.Helper()
+	assert.InEpsilon(fatal(t), got, want, epsilon)
+}
+
+// WithinDuration asserts that the times got and want differ by no more than
+// delta.
+func WithinDuration(t testingT, got, want time.Time, delta time.Duration) {
+	t.Helper()
+	assert.WithinDuration(fatal(t), got, want, delta)
+}
+
+// Nil asserts that got is nil.
+func Nil(t testingT, got interface{}) {
+	t.Helper()
+	assert.Nil(fatal(t), got)
+}
+
+// NotNil asserts that got is not nil.
+func NotNil(t testingT, got interface{}) {
+	t.Helper()
+	assert.NotNil(fatal(t), got)
+}
+
+// Empty asserts that got is empty.
+func Empty(t testingT, got interface{}) {
+	t.Helper()
+	assert.Empty(fatal(t), got)
+}
+
+// NotEmpty asserts that got is not empty.
+func NotEmpty(t testingT, got interface{}) {
+	t.Helper()
+	assert.NotEmpty(fatal(t), got)
+}
+
+// Panics asserts that fn panics.
+func Panics(t testingT, fn func()) {
+	t.Helper()
+	assert.Panics(fatal(t), fn)
+}
+
+// NotPanics asserts that fn does not panic.
+func NotPanics(t testingT, fn func()) {
+	t.Helper()
+	assert.NotPanics(fatal(t), fn)
+}
+
+// PanicsWithValue asserts that fn panics with a value equal to want.
+func PanicsWithValue(t testingT, fn func(), want interface{}, opts ...cmp.Option) {
+	t.Helper()
+	assert.PanicsWithValue(fatal(t), fn, want, opts...)
+}
+
+// PanicsWithError asserts that fn panics with an error whose message contains
+// the wanted string.
+func PanicsWithError(t testingT, fn func(), want string) {
+	t.Helper()
+	assert.PanicsWithError(fatal(t), fn, want)
+}
+
+// Eventually asserts that cond returns true within timeout, polling it every
+// interval.
+func Eventually(t testingT, cond func() bool, timeout, interval time.Duration) {
+	t.Helper()
+	assert.Eventually(fatal(t), cond, timeout, interval)
+}
+
+// Consistently asserts that cond keeps returning true for the whole duration,
+// polling it every interval.
+func Consistently(t testingT, cond func() bool, duration, interval time.Duration) {
+	t.Helper()
+	assert.Consistently(fatal(t), cond, duration, interval)
+}
+
+// EventuallyEqual asserts that fn returns a value equal to want within timeout,
+// polling it every interval.
+func EventuallyEqual[T any](t testingT, fn func() T, want T, timeout, interval time.Duration, opts ...cmp.Option) {
+	t.Helper()
+	assert.EventuallyEqual(fatal(t), fn, want, timeout, interval, opts...)
+}
+
+// fatal returns a testingT which reports failures with t.Fatal.
+func fatal(t testingT) testingT {
+	return assert.New(t).FailFast().T()
+}
@@ -0,0 +1,164 @@
+package require
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deliveroo/assert-go"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestEqual(t *testing.T) {
+	check(t, func(mt *mockTestingT) {
+		Equal(mt, 1, 1)
+	}, ``)
+
+	check(t,
+		func(mt *mockTestingT) {
+			id := 1
+			Equal(mt, id, 2)
+		},
+		`id (-got +want):`)
+}
+
+func TestScopedOptions(t *testing.T) {
+	type user struct{ ID int }
+
+	check(t, func(mt *mockTestingT) {
+		at := assert.WithOptions(mt, cmpopts.IgnoreFields(user{}, "ID"))
+		Equal(at, user{ID: 1}, user{ID: 2})
+	}, ``)
+}
+
+func TestNotNil(t *testing.T) {
+	check(t,
+		func(mt *mockTestingT) {
+			var user *struct{}
+			NotNil(mt, user)
+		},
+		`user was not nil`)
+}
+
+func TestContains(t *testing.T) {
+	check(t,
+		func(mt *mockTestingT) {
+			out := []string{"red"}
+			Contains(mt, out, "blue")
+		},
+		`out does not contain:`)
+}
+
+func TestJSONPath(t *testing.T) {
+	check(t,
+		func(mt *mockTestingT) {
+			resp := `{"id": 1}`
+			JSONPath(mt, resp, "id", 2)
+		},
+		`resp $.id (-got +want):`)
+}
+
+func TestJSONPathNotExists(t *testing.T) {
+	check(t,
+		func(mt *mockTestingT) {
+			resp := `{"password": "hunter2"}`
+			JSONPathNotExists(mt, resp, "password")
+		},
+		`resp $.password exists: "hunter2"`)
+}
+
+func TestErrorIs(t *testing.T) {
+	errNotFound := errors.New("not found")
+
+	check(t, func(mt *mockTestingT) {
+		ErrorIs(mt, fmt.Errorf("load: %w", errNotFound), errNotFound)
+	}, ``)
+
+	check(t,
+		func(mt *mockTestingT) {
+			err := errors.New("timeout")
+			ErrorIs(mt, err, errNotFound)
+		},
+		`err does not match`)
+}
+
+func TestEventuallyEqual(t *testing.T) {
+	check(t,
+		func(mt *mockTestingT) {
+			status := func() string { return "pending" }
+			EventuallyEqual(mt, status, "done", time.Millisecond, time.Millisecond)
+		},
+		`status (after 1ms) (-got +want):`)
+}
+
+func TestInDelta(t *testing.T) {
+	check(t,
+		func(mt *mockTestingT) {
+			price := 10.5
+			InDelta(mt, price, 10, 0.25)
+		},
+		`price (10.5) is not within 0.25 of 10 (difference 0.5)`)
+}
+
+func TestGreater(t *testing.T) {
+	check(t,
+		func(mt *mockTestingT) {
+			count := 1
+			Greater(mt, count, 2)
+		},
+		`count (1) is not greater than 2`)
+}
+
+func TestSorted(t *testing.T) {
+	check(t, func(mt *mockTestingT) {
+		Sorted(mt, []int{1, 2}, nil)
+	}, ``)
+
+	check(t,
+		func(mt *mockTestingT) {
+			ids := []int{2, 1}
+			Sorted(mt, ids, func(a, b int) bool { return a < b })
+		},
+		`ids is not sorted: ids[1] (1) is less than ids[0] (2)`)
+}
+
+func check(t *testing.T, fn func(mt *mockTestingT), want string) {
+	t.Helper()
+	mt := &mockTestingT{}
+	// Run fn in its own goroutine, as the testing package does, so that Fatal
+	// can stop it with runtime.Goexit.
+	var returned bool
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(mt)
+		returned = true
+	}()
+	<-done
+	if mt.err != "" {
+		t.Errorf("called Error: %s", mt.err)
+	}
+	if want == "" && mt.fatal != "" {
+		t.Errorf("called Fatal: %s", mt.fatal)
+	}
+	if want != "" && !strings.HasPrefix(mt.fatal, want) {
+		t.Errorf("fatal:\ngot:  %s\nwant prefix: %s", mt.fatal, want)
+	}
+	if want != "" && returned {
+		t.Error("the test continued after the assertion failed")
+	}
+}
+
+type mockTestingT struct {
+	err, fatal string
+}
+
+func (t *mockTestingT) Helper()                   {}
+func (t *mockTestingT) Error(args ...interface{}) { t.err = fmt.Sprint(args...) }
+func (t *mockTestingT) Fatal(args ...interface{}) {
+	t.fatal = fmt.Sprint(args...)
+	runtime.Goexit()
+}
@@ -0,0 +1,140 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// JSONSchema asserts that the JSON representation of subject is valid
+// according to a JSON Schema. Schemas without a "$schema" keyword are
+// interpreted as draft 2020-12, and "format" keywords are asserted.
+//
+// The schema may be given inline, either as raw JSON (as in JSONEqual) or as a
+// value to be marshaled to JSON, or as the path of a file. A relative path is
+// looked up in the current directory and then in testdata. For example:
+//
+//     assert.JSONSchema(t, resp.Body.String(), "user.schema.json")
+//
+// Every violation is reported, along with the location in subject at which it
+// occurred.
+func JSONSchema(t testingT, subject, schema interface{}) bool {
+	t.Helper()
+	subject, ok := decodeJSON(t, 1, subject)
+	if !ok {
+		return false
+	}
+	name, s, err := compileSchema(schema)
+	if err != nil {
+		t.Error(formatError(getArg(2)(), err.Error()))
+		return false
+	}
+	if name == "" {
+		name = getArg(2)()
+	}
+	err = s.Validate(subject)
+	var verr *jsonschema.ValidationError
+	if errors.As(err, &verr) {
+		msg := fmt.Sprintf("does not match schema %s:", name)
+		for _, v := range schemaViolations(verr) {
+			msg += "\n  " + v
+		}
+		t.Error(formatError(getArg(1)(), msg))
+		return false
+	} else if err != nil {
+		t.Error(formatError(getArg(1)(), err.Error()))
+		return false
+	}
+	return true
+}
+
+// compileSchema compiles a schema given to JSONSchema. If the schema was
+// loaded from a file, it also returns its path.
+func compileSchema(schema interface{}) (path string, s *jsonschema.Schema, err error) {
+	c := jsonschema.NewCompiler()
+	c.Draft = jsonschema.Draft2020
+	c.AssertFormat = true
+
+	if p, ok := schema.(string); ok && !isRawJSON(p) {
+		path, err = findSchema(p)
+		if err != nil {
+			return "", nil, err
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return "", nil, err
+		}
+		s, err = c.Compile(abs)
+		if err != nil {
+			return "", nil, fmt.Errorf("is not a valid JSON Schema: %v", err)
+		}
+		return path, s, nil
+	}
+
+	v, err := toJSON(schema)
+	if err != nil {
+		return "", nil, err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", nil, err
+	}
+	const url = "inline.schema.json"
+	if err := c.AddResource(url, bytes.NewReader(b)); err != nil {
+		return "", nil, fmt.Errorf("is not a valid JSON Schema: %v", err)
+	}
+	if s, err = c.Compile(url); err != nil {
+		return "", nil, fmt.Errorf("is not a valid JSON Schema: %v", err)
+	}
+	return "", s, nil
+}
+
+// findSchema returns the path of the schema file p, which may be relative to
+// the current directory or to testdata.
+func findSchema(p string) (string, error) {
+	candidates := []string{p}
+	if !filepath.IsAbs(p) {
+		candidates = append(candidates, filepath.Join(goldenDir, p))
+	}
+	for _, c := range candidates {
+		if _, err := os.Stat(c); err == nil {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("schema file %s does not exist", strings.Join(candidates, " or "))
+}
+
+// schemaViolations returns the individual violations in a validation error,
+// each prefixed with the location in the instance at which it occurred.
+func schemaViolations(err *jsonschema.ValidationError) []string {
+	seen := map[string]bool{}
+	var result []string
+	var walk func(*jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, c := range e.Causes {
+				walk(c)
+			}
+			return
+		}
+		loc := e.InstanceLocation
+		if loc == "" {
+			loc = "(root)"
+		}
+		v := loc + ": " + e.Message
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	walk(err)
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,92 @@
+package assert
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+const userSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["id", "email", "roles"],
+	"properties": {
+		"id": {"type": "integer"},
+		"email": {"type": "string", "format": "email"},
+		"roles": {"type": "array", "items": {"enum": ["admin", "member"]}}
+	}
+}`
+
+func TestJSONSchema(t *testing.T) {
+	assert(t, func(mt *mockTestingT) bool {
+		user := `{"id": 1, "email": "alice@example.com", "roles": ["admin"]}`
+		return JSONSchema(mt, user, userSchema)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			user := `{"id": "1", "email": "alice", "roles": ["admin", "owner"]}`
+			return JSONSchema(mt, user, userSchema)
+		},
+		removeLeadingTabs(`user does not match schema userSchema:
+		  /email: 'alice' is not valid 'email'
+		  /id: expected integer, but got string
+		  /roles/1: value must be one of "admin", "member"`),
+	)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			user := map[string]interface{}{"id": 1}
+			return JSONSchema(mt, user, userSchema)
+		},
+		removeLeadingTabs(`user does not match schema userSchema:
+		  (root): missing properties: 'email', 'roles'`),
+	)
+
+	t.Run("value schema", func(t *testing.T) {
+		schema := map[string]interface{}{"type": "array", "maxItems": 1}
+		assert(t,
+			func(mt *mockTestingT) bool {
+				return JSONSchema(mt, []int{1, 2}, schema)
+			},
+			"[]int{1, 2} does not match schema schema:\n"+
+				"  (root): maximum 1 items required, but found 2 items",
+		)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		dir, restore := withGoldenDir(t)
+		defer restore()
+		path := filepath.Join(dir, "user.schema.json")
+		if err := ioutil.WriteFile(path, []byte(userSchema), 0644); err != nil {
+			t.Fatal(err)
+		}
+		assert(t, func(mt *mockTestingT) bool {
+			return JSONSchema(mt, `{"id": 1, "email": "a@b.c", "roles": []}`, path)
+		}, ``)
+
+		// Relative paths are also looked up in testdata.
+		assert(t,
+			func(mt *mockTestingT) bool {
+				user := `{"id": 1.5, "email": "a@b.c", "roles": []}`
+				return JSONSchema(mt, user, "user.schema.json")
+			},
+			"user does not match schema "+path+":\n"+
+				"  /id: expected integer, but got number",
+		)
+
+		assert(t,
+			func(mt *mockTestingT) bool {
+				return JSONSchema(mt, `{}`, "missing.schema.json")
+			},
+			`"missing.schema.json" schema file missing.schema.json or `+filepath.Join(dir, "missing.schema.json")+` does not exist`)
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		assert(t,
+			func(mt *mockTestingT) bool {
+				return JSONSchema(mt, `{}`, `{"type": 1}`)
+			},
+			"`{\"type\": 1}` is not a valid JSON Schema:")
+	})
+}
@@ -0,0 +1,55 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Scoped is a testingT that applies a set of options to every assertion made
+// against it, in addition to the registered default options.
+type Scoped struct {
+	testingT
+	opts []cmp.Option
+	msgs []string
+}
+
+// WithOptions returns a testingT wrapping t, which applies opts to every
+// assertion made against it. Unlike RegisterOptions, the options do not affect
+// any other test, so it is safe to use with t.Parallel. For example:
+//
+//     at := assert.WithOptions(t, cmpopts.EquateEmpty())
+//     assert.Equal(at, got, want)
+//
+// Calls may be nested to layer further options. Options passed directly to an
+// assertion are applied in addition to those of the Scoped; where both
+// configure the error comparison (see CompareErrors), the innermost wins.
+//
+// Messages created with Msg or Context are added to every failure reported.
+func WithOptions(t testingT, opts ...cmp.Option) *Scoped {
+	msgs, opts := splitAnnotations(opts)
+	return &Scoped{testingT: t, opts: opts, msgs: msgs}
+}
+
+func (s *Scoped) unwrap() testingT { return s.testingT }
+
+// Error implements testingT.
+func (s *Scoped) Error(args ...interface{}) {
+	s.testingT.Helper()
+	s.testingT.Error(s.message(args))
+}
+
+// Fatal implements testingT.
+func (s *Scoped) Fatal(args ...interface{}) {
+	s.testingT.Helper()
+	s.testingT.Fatal(s.message(args))
+}
+
+func (s *Scoped) message(args []interface{}) string {
+	msg := fmt.Sprint(args...)
+	if len(s.msgs) > 0 {
+		msg = strings.Join(s.msgs, ": ") + ": " + msg
+	}
+	return msg
+}
@@ -0,0 +1,90 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestWithOptions(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	ignoreID := cmpopts.IgnoreFields(user{}, "ID")
+
+	assert(t, func(mt *mockTestingT) bool {
+		at := WithOptions(mt, ignoreID)
+		return Equal(at, user{ID: 1, Name: "Alice"}, user{ID: 2, Name: "Alice"})
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			at := WithOptions(mt, ignoreID)
+			u := user{ID: 1, Name: "Alice"}
+			return Equal(at, u, user{ID: 2, Name: "Bob"})
+		},
+		`u (-got +want):`)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			WithOptions(mt, ignoreID)
+			u := user{ID: 1, Name: "Alice"}
+			return Equal(mt, u, user{ID: 2, Name: "Alice"})
+		},
+		`u (-got +want):`)
+
+	t.Run("nested", func(t *testing.T) {
+		assert(t, func(mt *mockTestingT) bool {
+			at := WithOptions(WithOptions(mt, ignoreID), cmpopts.IgnoreFields(user{}, "Name"))
+			return ContainsAll(at, []user{{ID: 1, Name: "Alice"}}, []user{{ID: 2, Name: "Bob"}})
+		}, ``)
+	})
+
+	t.Run("error mode precedence", func(t *testing.T) {
+		errNotFound := errors.New("not found")
+		wrapped := fmt.Errorf("load: %w", errNotFound)
+		at := WithOptions(&mockTestingT{}, CompareErrors(ErrorsByIs))
+
+		assert(t, func(mt *mockTestingT) bool {
+			at := WithOptions(mt, CompareErrors(ErrorsByIs))
+			return Equal(at, wrapped, errNotFound)
+		}, ``)
+
+		assert(t, func(mt *mockTestingT) bool {
+			at := WithOptions(WithOptions(mt, CompareErrors(ErrorsByMessage)), CompareErrors(ErrorsByIs))
+			return Equal(at, wrapped, errNotFound)
+		}, ``)
+
+		assertModes(t, withDefaults(at, []cmp.Option{CompareErrors(ErrorsByMessage)}), errorComparers[ErrorsByMessage])
+	})
+}
+
+func TestContainsAllDefaults(t *testing.T) {
+	// ContainsAll applies the registered and scoped options, like Contains.
+	defer func(opts []cmp.Option) { defaultOpts = opts }(defaultOpts)
+	type user struct {
+		ID   int
+		Name string
+	}
+	got := []user{{ID: 1, Name: "Alice"}}
+	want := []user{{ID: 2, Name: "Alice"}}
+
+	assert(t, func(mt *mockTestingT) bool { return ContainsAll(mt, got, want) }, `got does not contain:`)
+
+	RegisterOptions(cmpopts.IgnoreFields(user{}, "ID"))
+	assert(t, func(mt *mockTestingT) bool { return ContainsAll(mt, got, want) }, ``)
+
+	defaultOpts = nil
+	assert(t, func(mt *mockTestingT) bool {
+		return ContainsAll(WithOptions(mt, cmpopts.IgnoreFields(user{}, "ID")), got, want)
+	}, ``)
+
+	// Errors are compared by message, as by the other assertions.
+	assert(t, func(mt *mockTestingT) bool {
+		return ContainsAll(mt, []error{errors.New("x")}, []error{errors.New("x")})
+	}, ``)
+}
@@ -0,0 +1,67 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/go-cmp/cmp"
+)
+
+// TOMLEqual asserts that got and want are equal when represented as TOML. If
+// either are strings, byte slices or io.Readers, they will be considered raw
+// TOML documents. Otherwise, they will be encoded as TOML before comparison.
+//
+// Key order, formatting, comments and the choice between inline and standard
+// tables are not significant. Dates and times are compared as strings in
+// RFC 3339 format. The JSON comparison options, such as IgnoreJSON, may be
+// used, and differences are reported as in JSONEqual.
+func TOMLEqual(t testingT, got, want interface{}, opts ...cmp.Option) bool {
+	t.Helper()
+	t, opts = annotate(t, opts)
+	got, ok := decodeTOML(t, 1, got)
+	if !ok {
+		return false
+	}
+	want, ok = decodeTOML(t, 2, want)
+	if !ok {
+		return false
+	}
+	return assertJSONEqual(t, getArg(1), got, want, opts)
+}
+
+// decodeTOML converts v, the value of argument arg of the assertion, to simple
+// JSON types as in fromTOML. If it can't be, it reports a failure and returns
+// false.
+func decodeTOML(t testingT, arg int, v interface{}) (interface{}, bool) {
+	t.Helper()
+	r, err := fromTOML(v)
+	if err != nil {
+		t.Error(formatError(getArg(arg)(), err.Error()))
+		return nil, false
+	}
+	return r, true
+}
+
+// fromTOML transforms v into simple JSON types, via its TOML representation.
+func fromTOML(v interface{}) (interface{}, error) {
+	raw, ok, err := rawInput(v)
+	if err != nil {
+		return nil, err
+	}
+	if s, isString := v.(string); isString {
+		raw, ok = []byte(s), true
+	}
+	if !ok {
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+			return nil, fmt.Errorf("cannot be represented as TOML: %v", err)
+		}
+		raw = buf.Bytes()
+	}
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("is not valid TOML: %v", err)
+	}
+	return normalizeTree(doc)
+}
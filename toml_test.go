@@ -0,0 +1,73 @@
+package assert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTOMLEqual(t *testing.T) {
+	cfg := `
+# Server configuration.
+title = "example"
+
+[server]
+host = "localhost"
+ports = [8000, 8001]
+started = 2020-01-02T03:04:05Z
+`
+
+	assert(t, func(mt *mockTestingT) bool {
+		return TOMLEqual(mt, cfg, `
+server = { started = 2020-01-02T03:04:05Z, ports = [ 8000, 8001 ], host = 'localhost' }
+title = 'example'
+`)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return TOMLEqual(mt, cfg, `
+title = "example"
+[server]
+host = "0.0.0.0"
+ports = [8000, 8001]
+started = 2020-01-02T03:04:05Z
+`)
+		},
+		removeLeadingTabs(`cfg (-got +want):
+		/server/host:
+		  - "localhost"
+		  + "0.0.0.0"`),
+	)
+
+	t.Run("values", func(t *testing.T) {
+		type server struct {
+			Host    string    `toml:"host"`
+			Ports   []int     `toml:"ports"`
+			Started time.Time `toml:"started"`
+		}
+		type config struct {
+			Title  string `toml:"title"`
+			Server server `toml:"server"`
+		}
+		want := config{
+			Title: "example",
+			Server: server{
+				Host:    "localhost",
+				Ports:   []int{8000, 8001},
+				Started: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		}
+		assert(t, func(mt *mockTestingT) bool {
+			return TOMLEqual(mt, []byte(cfg), want)
+		}, ``)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert(t,
+			func(mt *mockTestingT) bool {
+				cfg := "title = "
+				return TOMLEqual(mt, cfg, "")
+			},
+			"cfg is not valid TOML: toml:")
+	})
+}
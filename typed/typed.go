@@ -0,0 +1,133 @@
+// Package typed provides type-safe versions of the assertions in package
+// assert, using type parameters.
+//
+// The assertions behave exactly like their counterparts in package assert,
+// except that mismatched types are caught at compile time. For example,
+// typed.Equal(t, int64(1), 1) compiles (the untyped constant is converted to
+// int64), whereas typed.Equal(t, id, "1") for an int id does not.
+package typed
+
+import (
+	"time"
+
+	"github.com/deliveroo/assert-go"
+	"github.com/google/go-cmp/cmp"
+)
+
+// testingT is a simplified interface of the testing.T.
+type testingT interface {
+	Helper()
+	Error(args ...interface{})
+	Fatal(args ...interface{})
+}
+
+// Equal asserts that got and want are equal.
+func Equal[T any](t testingT, got, want T, opts ...cmp.Option) bool {
+	t.Helper()
+	return assert.Equal(t, got, want, opts...)
+}
+
+// NotEqual asserts that got and want are not equal.
+func NotEqual[T any](t testingT, got, notWant T, opts ...cmp.Option) bool {
+	t.Helper()
+	return assert.NotEqual(t, got, notWant, opts...)
+}
+
+// Contains asserts that the slice got contains want.
+func Contains[T any](t testingT, got []T, want T, opts ...cmp.Option) bool {
+	t.Helper()
+	return assert.Contains(t, got, want, opts...)
+}
+
+// ContainsAll asserts that the slice got contains all items of want.
+func ContainsAll[T any](t testingT, got, want []T, opts ...cmp.Option) bool {
+	t.Helper()
+	return assert.ContainsAll(t, got, want, opts...)
+}
+
+// ElementsMatch asserts that got and want contain the same elements, ignoring
+// their order: that got contains all the elements of want, as reported by
+// ContainsAll, and no others.
+func ElementsMatch[T any](t testingT, got, want []T, opts ...cmp.Option) bool {
+	t.Helper()
+	if !assert.ContainsAll(t, got, want, opts...) {
+		return false
+	}
+	if len(got) > len(want) {
+		msg := assert.Msg("got has elements not in want")
+		return assert.Equal(t, got, want, append(opts, msg)...)
+	}
+	return true
+}
+
+// MapHasKey asserts that the map got contains the key want.
+func MapHasKey[K comparable, V any](t testingT, got map[K]V, want K) bool {
+	t.Helper()
+	if _, ok := got[want]; ok {
+		return true
+	}
+	keys := make([]K, 0, len(got))
+	for k := range got {
+		keys = append(keys, k)
+	}
+	return assert.Contains(t, keys, want)
+}
+
+// Ordered is a constraint that permits the types compared by Greater, Less and
+// Between: integers, floats and strings, including types defined in terms of
+// them such as time.Duration. Use After, Before and BetweenTimes to compare
+// time.Times.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// Greater asserts that got is greater than want.
+func Greater[T Ordered](t testingT, got, want T) bool {
+	t.Helper()
+	return assert.Greater(t, got, want)
+}
+
+// GreaterOrEqual asserts that got is greater than or equal to want.
+func GreaterOrEqual[T Ordered](t testingT, got, want T) bool {
+	t.Helper()
+	return assert.GreaterOrEqual(t, got, want)
+}
+
+// Less asserts that got is less than want.
+func Less[T Ordered](t testingT, got, want T) bool {
+	t.Helper()
+	return assert.Less(t, got, want)
+}
+
+// LessOrEqual asserts that got is less than or equal to want.
+func LessOrEqual[T Ordered](t testingT, got, want T) bool {
+	t.Helper()
+	return assert.LessOrEqual(t, got, want)
+}
+
+// Between asserts that got is between lo and hi, inclusive.
+func Between[T Ordered](t testingT, got, lo, hi T) bool {
+	t.Helper()
+	return assert.Between(t, got, lo, hi)
+}
+
+// After asserts that the time got is after want.
+func After(t testingT, got, want time.Time) bool {
+	t.Helper()
+	return assert.Greater(t, got, want)
+}
+
+// Before asserts that the time got is before want.
+func Before(t testingT, got, want time.Time) bool {
+	t.Helper()
+	return assert.Less(t, got, want)
+}
+
+// BetweenTimes asserts that the time got is between lo and hi, inclusive.
+func BetweenTimes(t testingT, got, lo, hi time.Time) bool {
+	t.Helper()
+	return assert.Between(t, got, lo, hi)
+}
@@ -0,0 +1,181 @@
+package typed
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEqual(t *testing.T) {
+	check(t, func(mt *mockTestingT) bool {
+		return Equal(mt, int64(1), 1)
+	}, ``)
+
+	check(t,
+		func(mt *mockTestingT) bool {
+			id := 1
+			return Equal(mt, id, 2)
+		},
+		`id (-got +want):`)
+
+	check(t,
+		func(mt *mockTestingT) bool {
+			id := 1
+			return Equal[int](mt, id, 2)
+		},
+		`id (-got +want):`)
+}
+
+func TestNotEqual(t *testing.T) {
+	check(t, func(mt *mockTestingT) bool {
+		return NotEqual(mt, "a", "b")
+	}, ``)
+
+	check(t,
+		func(mt *mockTestingT) bool {
+			name := "a"
+			return NotEqual(mt, name, "a")
+		},
+		`name should not equal "a"`)
+}
+
+func TestContains(t *testing.T) {
+	check(t, func(mt *mockTestingT) bool {
+		return Contains(mt, []string{"red", "blue"}, "red")
+	}, ``)
+
+	check(t,
+		func(mt *mockTestingT) bool {
+			colors := []string{"red", "blue"}
+			return Contains(mt, colors, "green")
+		},
+		`colors does not contain:`)
+}
+
+func TestContainsAll(t *testing.T) {
+	check(t, func(mt *mockTestingT) bool {
+		return ContainsAll(mt, []int{1, 2, 3}, []int{3, 1})
+	}, ``)
+
+	check(t,
+		func(mt *mockTestingT) bool {
+			ids := []int{1, 2, 3}
+			return ContainsAll(mt, ids, []int{4})
+		},
+		`ids does not contain:`)
+}
+
+func TestElementsMatch(t *testing.T) {
+	check(t, func(mt *mockTestingT) bool {
+		return ElementsMatch(mt, []int{1, 2, 3}, []int{3, 1, 2})
+	}, ``)
+
+	check(t,
+		func(mt *mockTestingT) bool {
+			ids := []int{1, 2}
+			return ElementsMatch(mt, ids, []int{3, 1})
+		},
+		`ids does not contain:`)
+
+	check(t,
+		func(mt *mockTestingT) bool {
+			ids := []int{1, 2, 3}
+			return ElementsMatch(mt, ids, []int{3, 1})
+		},
+		`got has elements not in want: ids (-got +want):`)
+}
+
+func TestMapHasKey(t *testing.T) {
+	check(t, func(mt *mockTestingT) bool {
+		return MapHasKey(mt, map[string]int{"a": 1}, "a")
+	}, ``)
+
+	check(t,
+		func(mt *mockTestingT) bool {
+			counts := map[string]int{"a": 1}
+			return MapHasKey(mt, counts, "b")
+		},
+		`counts does not contain:`)
+}
+
+func TestOrder(t *testing.T) {
+	check(t, func(mt *mockTestingT) bool {
+		return Greater(mt, 2*time.Second, time.Second)
+	}, ``)
+
+	check(t, func(mt *mockTestingT) bool {
+		return Greater(mt, int64(1<<62+1), 1<<62)
+	}, ``)
+
+	check(t,
+		func(mt *mockTestingT) bool {
+			timeout := time.Duration(1 << 60)
+			return Less(mt, timeout, timeout)
+		},
+		`timeout (320255h58m24.606846976s) is not less than 320255h58m24.606846976s`)
+
+	check(t,
+		func(mt *mockTestingT) bool {
+			total := 3.5
+			return LessOrEqual(mt, total, 3)
+		},
+		`total (3.5) is not less than or equal to 3`)
+}
+
+func TestBetween(t *testing.T) {
+	check(t, func(mt *mockTestingT) bool {
+		return Between(mt, 5, 1, 10)
+	}, ``)
+
+	check(t,
+		func(mt *mockTestingT) bool {
+			name := "z"
+			return Between(mt, name, "a", "m")
+		},
+		`name ("z") is not between "a" and "m"`)
+
+	type status string
+	check(t, func(mt *mockTestingT) bool {
+		return Between(mt, status("b"), "a", "c")
+	}, ``)
+}
+
+func TestTimes(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	check(t, func(mt *mockTestingT) bool {
+		return After(mt, end, start)
+	}, ``)
+	check(t, func(mt *mockTestingT) bool {
+		return BetweenTimes(mt, start.Add(time.Minute), start, end)
+	}, ``)
+
+	check(t,
+		func(mt *mockTestingT) bool {
+			created := end
+			return Before(mt, created, start)
+		},
+		`created (2024-01-01 10:00:00 +0000 UTC) is not less than 2024-01-01 09:00:00 +0000 UTC`)
+}
+
+func check(t *testing.T, fn func(mt *mockTestingT) bool, want string) {
+	t.Helper()
+	mt := &mockTestingT{}
+	ret := fn(mt)
+	if want != "" && !strings.HasPrefix(mt.err, want) {
+		t.Errorf("error:\ngot:  %s\nwant prefix: %s", mt.err, want)
+	}
+	if ret != (want == "") {
+		t.Errorf("returned %v, want %v", ret, (want == ""))
+	}
+}
+
+type mockTestingT struct {
+	err, fatal string
+}
+
+func (t *mockTestingT) Helper()                   {}
+func (t *mockTestingT) Error(args ...interface{}) { t.err = fmt.Sprint(args...) }
+func (t *mockTestingT) Fatal(args ...interface{}) { t.fatal = fmt.Sprint(args...) }
@@ -0,0 +1,120 @@
+package assert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLEqual asserts that got and want are equal when represented as YAML. If
+// either are strings, byte slices or io.Readers, they will be considered raw
+// YAML documents. Otherwise, they will be marshaled to YAML before comparison.
+//
+// Key order, formatting, comments and anchors are not significant. A stream of
+// several documents (separated by "---") is compared as an array of documents.
+// The JSON comparison options, such as IgnoreJSON, may be used, and
+// differences are reported as in JSONEqual.
+func YAMLEqual(t testingT, got, want interface{}, opts ...cmp.Option) bool {
+	t.Helper()
+	t, opts = annotate(t, opts)
+	got, ok := decodeYAML(t, 1, got)
+	if !ok {
+		return false
+	}
+	want, ok = decodeYAML(t, 2, want)
+	if !ok {
+		return false
+	}
+	return assertJSONEqual(t, getArg(1), got, want, opts)
+}
+
+// decodeYAML converts v, the value of argument arg of the assertion, to simple
+// JSON types as in fromYAML. If it can't be, it reports a failure and returns
+// false.
+func decodeYAML(t testingT, arg int, v interface{}) (interface{}, bool) {
+	t.Helper()
+	r, err := fromYAML(v)
+	if err != nil {
+		t.Error(formatError(getArg(arg)(), err.Error()))
+		return nil, false
+	}
+	return r, true
+}
+
+// fromYAML transforms v into simple JSON types, via its YAML representation.
+func fromYAML(v interface{}) (interface{}, error) {
+	raw, ok, err := rawInput(v)
+	if err != nil {
+		return nil, err
+	}
+	if s, isString := v.(string); isString {
+		raw, ok = []byte(s), true
+	}
+	if !ok {
+		b, err := yaml.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot be represented as YAML: %v", err)
+		}
+		raw = b
+	}
+	var docs []interface{}
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	for {
+		var doc interface{}
+		if err := dec.Decode(&doc); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("is not valid YAML: %v", err)
+		}
+		docs = append(docs, doc)
+	}
+	var r interface{}
+	switch len(docs) {
+	case 0:
+	case 1:
+		r = docs[0]
+	default:
+		r = docs
+	}
+	return normalizeTree(r)
+}
+
+// normalizeTree converts a decoded YAML or TOML document to simple JSON types,
+// so that it can be compared using the JSON assertions' options and diff.
+func normalizeTree(v interface{}) (interface{}, error) {
+	r, err := toJSON(stringKeys(v))
+	if err != nil {
+		return nil, fmt.Errorf("cannot be represented as JSON: %v", err)
+	}
+	return r, nil
+}
+
+// stringKeys returns a copy of v in which maps with keys which aren't strings
+// (as decoded from YAML, e.g. `1: one`) have them formatted as strings.
+func stringKeys(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			m[fmt.Sprint(k)] = stringKeys(e)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			m[k] = stringKeys(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(x))
+		for i, e := range x {
+			a[i] = stringKeys(e)
+		}
+		return a
+	}
+	return v
+}
@@ -0,0 +1,82 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestYAMLEqual(t *testing.T) {
+	manifest := `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web # the public service
+  labels: {app: web, tier: frontend}
+spec:
+  ports:
+    - port: 80
+      targetPort: 8080
+`
+
+	assert(t, func(mt *mockTestingT) bool {
+		return YAMLEqual(mt, manifest, `
+kind: Service
+apiVersion: v1
+metadata:
+  labels:
+    tier: frontend
+    app: web
+  name: web
+spec:
+  ports: [{targetPort: 8080, port: 80}]
+`)
+	}, ``)
+
+	assert(t,
+		func(mt *mockTestingT) bool {
+			return YAMLEqual(mt, manifest, strings.Replace(manifest, "8080", "9090", 1))
+		},
+		removeLeadingTabs(`manifest (-got +want):
+		/spec/ports/0/targetPort:
+		  - 8080
+		  + 9090`),
+	)
+
+	t.Run("values", func(t *testing.T) {
+		type config struct {
+			Name  string   `yaml:"name"`
+			Hosts []string `yaml:"hosts"`
+		}
+		cfg := config{Name: "api", Hosts: []string{"a", "b"}}
+		assert(t, func(mt *mockTestingT) bool {
+			return YAMLEqual(mt, cfg, []byte("name: api\nhosts:\n  - a\n  - b\n"))
+		}, ``)
+		assert(t, func(mt *mockTestingT) bool {
+			return YAMLEqual(mt, map[int]string{1: "one"}, strings.NewReader(`"1": one`))
+		}, ``)
+	})
+
+	t.Run("multiple documents", func(t *testing.T) {
+		assert(t, func(mt *mockTestingT) bool {
+			return YAMLEqual(mt, "a: 1\n---\nb: 2\n", []interface{}{
+				map[string]int{"a": 1},
+				map[string]int{"b": 2},
+			})
+		}, ``)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		assert(t, func(mt *mockTestingT) bool {
+			return YAMLEqual(mt, "id: 1\nname: a", "id: 2\nname: a", IgnoreJSON("/id"))
+		}, ``)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert(t,
+			func(mt *mockTestingT) bool {
+				cfg := "a: [1"
+				return YAMLEqual(mt, cfg, "a: 1")
+			},
+			"cfg is not valid YAML: yaml: line 1: did not find expected ',' or ']'")
+	})
+}